@@ -0,0 +1,329 @@
+package audio
+
+import (
+	"errors"
+	"math"
+	"musicaltyper-go/game/clock"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/mix"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+/*
+Q. Why not mix.Music?
+A. mix.Music can't tell its playback position and can't change its rate.
+   Player decodes whole song into memory and feeds it to the mixer by itself.
+*/
+
+// chunkHeader mirrors layout of Mix_Chunk to read decoded samples
+type chunkHeader struct {
+	allocated int32
+	buf       *uint8
+	len       uint32
+	volume    uint8
+}
+
+// Player plays a song decoded in memory with changeable rate. It also works as clock.Clock of song time.
+type Player struct {
+	mutex sync.Mutex
+
+	chunk     *mix.Chunk
+	samples   []int16
+	channels  int
+	frequency float64
+	frames    int
+
+	position float64
+	rate     float64
+	volume   float64
+	paused   bool
+
+	// fadeFrames is length of fading out, and fadeRemaining is frames until silence. Zero while not fading.
+	fadeFrames    float64
+	fadeRemaining float64
+
+	// stretcher keeps pitch while rate is changed, or nil to resample
+	stretcher *stretcher
+
+	lastFillPosition float64
+	lastFillFrames   int
+	lastFillTime     time.Time
+}
+
+// Player is a song clock beside ones in package clock
+var _ clock.Clock = (*Player)(nil)
+
+// ErrUnsupportedFormat is returned when the mixer isn't opened with signed 16-bit samples
+var ErrUnsupportedFormat = errors.New("audio: mixer format must be signed 16-bit")
+
+// Load decodes the song. The mixer must be opened before.
+func Load(Path string) (*Player, error) {
+	Frequency, Format, Channels, _, Err := mix.QuerySpec()
+	if Err != nil {
+		return nil, Err
+	}
+	if Format != sdl.AUDIO_S16SYS {
+		return nil, ErrUnsupportedFormat
+	}
+
+	Chunk, Err := mix.LoadWAV(Path)
+	if Err != nil {
+		return nil, Err
+	}
+
+	Header := (*chunkHeader)(unsafe.Pointer(Chunk))
+	Length := int(Header.len / 2)
+	Samples := (*[1 << 30]int16)(unsafe.Pointer(Header.buf))[:Length:Length]
+
+	return &Player{
+		chunk:     Chunk,
+		samples:   Samples,
+		channels:  Channels,
+		frequency: float64(Frequency),
+		frames:    Length / Channels,
+		rate:      1,
+		volume:    1,
+		paused:    true,
+	}, nil
+}
+
+// Play starts playing from current position
+func (p *Player) Play() {
+	attach(p)
+	p.Resume()
+}
+
+// Now returns playback position in seconds, interpolated inside the buffer being played
+func (p *Player) Now() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.paused || p.lastFillFrames == 0 {
+		return p.position / p.frequency
+	}
+
+	Elapsed := math.Min(time.Now().Sub(p.lastFillTime).Seconds()*p.frequency, float64(p.lastFillFrames))
+	return (p.lastFillPosition + Elapsed*p.rate) / p.frequency
+}
+
+// Pause stops playing
+func (p *Player) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	//The buffer being played will have been played out.
+	p.paused = true
+	p.lastFillFrames = 0
+}
+
+// Resume restarts playing
+func (p *Player) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.paused = false
+}
+
+// IsPaused returns whether Player is stopped
+func (p *Player) IsPaused() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.paused
+}
+
+// Seek moves playback position in seconds. Fading out is cancelled.
+func (p *Player) Seek(Position float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.position = math.Max(0, math.Min(float64(p.frames), Position*p.frequency))
+	p.lastFillFrames = 0
+	p.fadeFrames = 0
+	if p.stretcher != nil {
+		p.stretcher.reset(p.position)
+	}
+}
+
+// SetRate changes playback rate. Pitch changes together unless preserving pitch.
+func (p *Player) SetRate(Rate float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.rate = Rate
+}
+
+// SetPreservePitch switches whether changing rate keeps pitch by time-stretching, or changes pitch by resampling
+func (p *Player) SetPreservePitch(Preserve bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !Preserve {
+		p.stretcher = nil
+		return
+	}
+	if p.stretcher == nil {
+		p.stretcher = newStretcher(p.channels)
+		p.stretcher.reset(p.position)
+	}
+}
+
+// Rate returns playback rate
+func (p *Player) Rate() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.rate
+}
+
+// SetVolume changes volume from 0 to 1
+func (p *Player) SetVolume(Volume float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.volume = Volume
+}
+
+// FadeOut lowers volume to silence in the seconds, and pauses
+func (p *Player) FadeOut(Seconds float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.fadeFrames = math.Max(1, Seconds*p.frequency)
+	p.fadeRemaining = p.fadeFrames
+}
+
+// IsFading returns whether fading out hasn't finished. Fading progresses only while playing.
+func (p *Player) IsFading() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.fadeFrames > 0
+}
+
+// Length returns length of the song in seconds
+func (p *Player) Length() float64 {
+	return float64(p.frames) / p.frequency
+}
+
+// IsFinished returns whether the song has been played to the end
+func (p *Player) IsFinished() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.position >= float64(p.frames)
+}
+
+// Free stops playing and releases the decoded song
+func (p *Player) Free() {
+	detach(p)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.chunk != nil {
+		p.chunk.Free()
+		p.chunk = nil
+		p.samples = nil
+		p.frames = 0
+		p.position = 0
+	}
+}
+
+// fill writes next samples into the stream. Called from audio thread.
+func (p *Player) fill(Stream []int16) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	OutFrames := len(Stream) / p.channels
+	p.lastFillPosition = p.position
+	p.lastFillFrames = OutFrames
+	p.lastFillTime = time.Now()
+
+	if p.paused {
+		p.lastFillFrames = 0
+		return
+	}
+
+	for i := 0; i < OutFrames; i++ {
+		Index := int(p.position)
+		if Index+1 >= p.frames {
+			p.position = float64(p.frames)
+			return
+		}
+
+		Volume := p.volume
+		if p.fadeFrames > 0 {
+			Volume *= p.fadeRemaining / p.fadeFrames
+			p.fadeRemaining--
+			if p.fadeRemaining <= 0 {
+				p.fadeFrames = 0
+				p.paused = true
+				return
+			}
+		}
+
+		if p.stretcher != nil {
+			Frame := p.stretcher.next(p.samples, p.channels, p.rate)
+			for c := 0; c < p.channels; c++ {
+				Stream[i*p.channels+c] = int16(Frame[c] * Volume)
+			}
+			p.position += p.rate
+			continue
+		}
+
+		Fraction := p.position - float64(Index)
+		for c := 0; c < p.channels; c++ {
+			var (
+				Current = float64(p.samples[Index*p.channels+c])
+				Next    = float64(p.samples[(Index+1)*p.channels+c])
+			)
+			Stream[i*p.channels+c] = int16((Current + (Next-Current)*Fraction) * Volume)
+		}
+		p.position += p.rate
+	}
+}
+
+var (
+	hookOnce     sync.Once
+	currentMutex sync.Mutex
+	current      *Player
+)
+
+// attach makes Player the one the mixer plays. The mixer can have only one music hook, so it's shared.
+func attach(p *Player) {
+	hookOnce.Do(func() {
+		mix.HookMusic(hook)
+	})
+
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	current = p
+}
+
+func detach(p *Player) {
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+	if current == p {
+		current = nil
+	}
+}
+
+// hook is called from audio thread. Never call SDL_mixer functions here.
+func hook(Stream []uint8) {
+	currentMutex.Lock()
+	defer currentMutex.Unlock()
+
+	for i := range Stream {
+		Stream[i] = 0
+	}
+	if current == nil || len(Stream) < 2 {
+		return
+	}
+
+	Samples := (*[1 << 30]int16)(unsafe.Pointer(&Stream[0]))[: len(Stream)/2 : len(Stream)/2]
+	current.fill(Samples)
+}
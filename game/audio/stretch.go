@@ -0,0 +1,84 @@
+package audio
+
+import "math"
+
+const (
+	// grainFrames is length of a grain cut from the song. About 46ms at 44100Hz.
+	grainFrames = 2048
+	// hopFrames is interval of grains in output. Hann windows overlapped by half sum up to 1.
+	hopFrames = grainFrames / 2
+)
+
+/*
+Q. How is the song stretched without changing pitch?
+A. Overlap-add: grains windowed by Hann are cut from the song at intervals of hop * rate,
+   and overlapped at intervals of hop in output. Each grain keeps original pitch.
+*/
+
+// stretcher changes song speed keeping its pitch
+type stretcher struct {
+	window      []float64
+	accumulated []float64
+	output      []float64
+	ready       []float64
+
+	// readPosition is frame of the song where next grain begins
+	readPosition float64
+}
+
+func newStretcher(Channels int) *stretcher {
+	Window := make([]float64, grainFrames)
+	for i := range Window {
+		Window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/grainFrames)
+	}
+
+	return &stretcher{
+		window:      Window,
+		accumulated: make([]float64, grainFrames*Channels),
+		output:      make([]float64, hopFrames*Channels),
+	}
+}
+
+// reset throws away grains and begins at the frame
+func (s *stretcher) reset(Position float64) {
+	for i := range s.accumulated {
+		s.accumulated[i] = 0
+	}
+	s.ready = nil
+	s.readPosition = Position
+}
+
+// next returns next output frame
+func (s *stretcher) next(Samples []int16, Channels int, Rate float64) []float64 {
+	if len(s.ready) == 0 {
+		s.addGrain(Samples, Channels, Rate)
+	}
+	Frame := s.ready[:Channels]
+	s.ready = s.ready[Channels:]
+	return Frame
+}
+
+// addGrain overlaps next grain, and makes output of a hop ready
+func (s *stretcher) addGrain(Samples []int16, Channels int, Rate float64) {
+	var (
+		Begin  = int(s.readPosition) * Channels
+		Length = len(Samples)
+	)
+
+	for i := 0; i < grainFrames*Channels; i++ {
+		if Begin+i >= Length {
+			break
+		}
+		s.accumulated[i] += s.window[i/Channels] * float64(Samples[Begin+i])
+	}
+
+	Hop := hopFrames * Channels
+	copy(s.output, s.accumulated[:Hop])
+	copy(s.accumulated, s.accumulated[Hop:])
+	for i := len(s.accumulated) - Hop; i < len(s.accumulated); i++ {
+		s.accumulated[i] = 0
+	}
+
+	s.ready = s.output
+	s.readPosition += hopFrames * Rate
+}
@@ -0,0 +1,73 @@
+package autoplay
+
+import (
+	"math"
+	"math/rand"
+	"musicaltyper-go/game/beatmap"
+)
+
+const (
+	// DefaultKPS is typing speed of Typist when not specified
+	DefaultKPS = 8.0
+	// DefaultJitter is how much intervals of Typist vary when not specified
+	DefaultJitter = 0.15
+
+	// reactionKeys is how many keys long Typist takes to start typing a new sentence
+	reactionKeys = 2.5
+	// minimumIntervalRate is the shortest interval relative to average, to keep keys in order
+	minimumIntervalRate = 0.2
+)
+
+// Typist types sentences like a human at given speed
+type Typist struct {
+	// KPS is average typing speed in keys per second of song time
+	KPS float64
+	// Jitter is standard deviation of key intervals relative to average
+	Jitter float64
+
+	random   *rand.Rand
+	nextTime float64
+	// sentence is the one being typed
+	sentence *beatmap.Sentence
+}
+
+// NewTypist makes Typist. The same seed makes the same timing.
+func NewTypist(KPS, Jitter float64, Seed int64) *Typist {
+	return &Typist{
+		KPS:    KPS,
+		Jitter: Jitter,
+		random: rand.New(rand.NewSource(Seed)),
+	}
+}
+
+// Next returns key to type in the sentence shown from Start, and its time before Now. ok is false if nothing should be typed yet.
+// Call it repeatedly passing the key to the game until ok is false.
+func (t *Typist) Next(Now, Start float64, Sentence *beatmap.Sentence, isInputDisabled bool) (Key byte, Time float64, ok bool) {
+	if isInputDisabled || Sentence == nil || Sentence.IsFinished {
+		return 0, 0, false
+	}
+
+	if Sentence != t.sentence {
+		//Typist reacts to a new sentence before typing.
+		t.sentence = Sentence
+		t.nextTime = Start + t.interval()*reactionKeys
+	}
+	if t.nextTime >= Now {
+		return 0, 0, false
+	}
+
+	Roma := Sentence.GetShortestRemainingRoma()
+	if Roma == "" {
+		return 0, 0, false
+	}
+
+	Time = t.nextTime
+	t.nextTime += t.interval()
+	return Roma[0], Time, true
+}
+
+// interval returns time until next key with jitter
+func (t *Typist) interval() float64 {
+	Average := 1 / t.KPS
+	return Average * math.Max(minimumIntervalRate, 1+t.random.NormFloat64()*t.Jitter)
+}
@@ -21,6 +21,13 @@ type Beatmap struct {
 	Properties map[string]string
 	Notes      []*Note
 	Sections   []*Section
+
+	// Fingerprint identifies the chart. It changes when timing, text or song changes.
+	Fingerprint string
+	// ChartID identifies the chart across its versions, made from title and song
+	ChartID string
+	// Path is file path which the beatmap was loaded from
+	Path string
 }
 
 // NewBeatmap makes empty Beatmap
@@ -157,6 +164,9 @@ func LoadMap(path string) *Beatmap {
 		logger.FatalError("Please fix above issues. Exiting.")
 	}
 
+	Result.computeIdentity()
+	Result.Path = path
+
 	return Result
 }
 
@@ -193,3 +203,23 @@ func detectEncoding(path string) bool {
 		return false //won't reach here.
 	}
 }
+
+// Clone makes Beatmap which has same notes with fresh typing state
+func (b *Beatmap) Clone() *Beatmap {
+	Result := NewBeatmap()
+	Result.Fingerprint = b.Fingerprint
+	Result.ChartID = b.ChartID
+	Result.Path = b.Path
+
+	for k, v := range b.Properties {
+		Result.Properties[k] = v
+	}
+	for _, v := range b.Notes {
+		Result.Notes = append(Result.Notes, v.clone())
+	}
+	for _, v := range b.Sections {
+		Section := *v
+		Result.Sections = append(Result.Sections, &Section)
+	}
+	return Result
+}
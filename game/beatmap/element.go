@@ -65,3 +65,16 @@ func newSection(Sec float64, ID string) *Section {
 	Result.ID = ID
 	return Result
 }
+
+func (n *Note) clone() *Note {
+	Result := *n
+	Result.Reset()
+	return &Result
+}
+
+// Reset clears typing state of the note
+func (n *Note) Reset() {
+	if n.Sentence != nil {
+		n.Sentence = NewSentence(n.Sentence.OriginalSentence, n.Sentence.HiraganaSentence)
+	}
+}
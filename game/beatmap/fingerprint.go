@@ -0,0 +1,70 @@
+package beatmap
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"musicaltyper-go/game/logger"
+	"os"
+)
+
+const (
+	// VersionPropertyName is name of beatmap property which chart authors bump on every release
+	VersionPropertyName = "version"
+)
+
+/*
+Q. Why not hash of the beatmap file?
+A. Re-encoding to UTF-8, or editing comments and spaces changes the file but not the chart.
+   Fingerprint is made only from what affects play: notes, sections and song audio.
+*/
+
+// computeIdentity sets Fingerprint and ChartID from parsed notes, sections and song audio
+func (b *Beatmap) computeIdentity() {
+	AudioHash := hashAudio(b.Properties["song_data"])
+
+	Hash := sha256.New()
+	for _, v := range b.Notes {
+		fmt.Fprintf(Hash, "N%d %d %q", v.Type, toMillisecond(v.Time), v.Caption)
+		if v.Sentence != nil {
+			fmt.Fprintf(Hash, " %q %q", v.Sentence.OriginalSentence, v.Sentence.HiraganaSentence)
+		}
+		io.WriteString(Hash, "\n")
+	}
+	for _, v := range b.Sections {
+		fmt.Fprintf(Hash, "S%d %q\n", toMillisecond(v.Time), v.ID)
+	}
+	fmt.Fprintf(Hash, "A%s\n", AudioHash)
+	b.Fingerprint = fmt.Sprintf("%x", Hash.Sum(nil))
+
+	b.ChartID = fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%q %s", b.Properties["title"], AudioHash))))[:16]
+}
+
+// Version returns version which chart author declared, or empty string
+func (b *Beatmap) Version() string {
+	return b.Properties[VersionPropertyName]
+}
+
+// hashAudio returns SHA-256 digest of the song file, or empty string if it can't be read
+func hashAudio(Path string) string {
+	Logger := logger.NewLogger("HashAudio")
+
+	File, Err := os.Open(Path)
+	if Err != nil {
+		Logger.Warn("Couldn't read song to identify the beatmap: " + Err.Error())
+		return ""
+	}
+	defer File.Close()
+
+	Hash := sha256.New()
+	if _, Err := io.Copy(Hash, File); Err != nil {
+		Logger.Warn("Couldn't read song to identify the beatmap: " + Err.Error())
+		return ""
+	}
+	return fmt.Sprintf("%x", Hash.Sum(nil))
+}
+
+func toMillisecond(Time float64) int64 {
+	return int64(math.Round(Time * 1000))
+}
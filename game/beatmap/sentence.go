@@ -171,3 +171,38 @@ func printRomaLog(msg string) {
 func length(s string) int {
 	return utf8.RuneCountInString(s)
 }
+
+// GetShortestRemainingRoma returns the shortest roman string to finish the sentence from current state
+func (s *Sentence) GetShortestRemainingRoma() string {
+	Count := len(s.SolvedSentence)
+	if s.CurrentCharacterIndex >= Count {
+		return ""
+	}
+
+	//Shortest[i] is the shortest roman to type from i-th character
+	Shortest := make([]string, Count+1)
+	Reachable := make([]bool, Count+1)
+	Reachable[Count] = true
+
+	for i := Count - 1; i >= s.CurrentCharacterIndex; i-- {
+		Character := s.SolvedSentence[i]
+		TypedLength := 0
+		if i == s.CurrentCharacterIndex {
+			TypedLength = Character.TypingIndex
+		}
+
+		for _, Style := range Character.RomaStyles {
+			Next := i + Style.Forwards
+			if Next > Count || !Reachable[Next] || len(Style.Roma) <= TypedLength {
+				continue
+			}
+
+			Candidate := Style.Roma[TypedLength:] + Shortest[Next]
+			if !Reachable[i] || len(Candidate) < len(Shortest[i]) {
+				Shortest[i] = Candidate
+				Reachable[i] = true
+			}
+		}
+	}
+	return Shortest[s.CurrentCharacterIndex]
+}
@@ -7,6 +7,9 @@ import (
 	"musicaltyper-go/game/logger"
 )
 
+// RomaTableID identifies the roman table in GetRoma and GetShortStyleRoma. Change this when editing them.
+const RomaTableID = "default-1"
+
 // Solve divides hiragana string to slice of Character
 func Solve(HiraganaSentence string) []*Character {
 	Result := make([]*Character, 0)
@@ -0,0 +1,11 @@
+package clock
+
+// Clock tells current song time in seconds
+type Clock interface {
+	Now() float64
+
+	// Pause stops the clock
+	Pause()
+	// Resume restarts the stopped clock
+	Resume()
+}
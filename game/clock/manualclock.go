@@ -0,0 +1,34 @@
+package clock
+
+// ManualClock is Clock which moves only when told, mainly for tests
+type ManualClock struct {
+	now float64
+}
+
+// NewManualClock makes ManualClock stopped at specified time
+func NewManualClock(Now float64) *ManualClock {
+	return &ManualClock{
+		now: Now,
+	}
+}
+
+// Now returns time set last
+func (c *ManualClock) Now() float64 {
+	return c.now
+}
+
+// Set overrides current time
+func (c *ManualClock) Set(Now float64) {
+	c.now = Now
+}
+
+// Advance moves current time forward by delta seconds
+func (c *ManualClock) Advance(Delta float64) {
+	c.now += Delta
+}
+
+// Pause does nothing, because ManualClock never moves by itself
+func (c *ManualClock) Pause() {}
+
+// Resume does nothing, because ManualClock never moves by itself
+func (c *ManualClock) Resume() {}
@@ -0,0 +1,86 @@
+package clock
+
+import (
+	"math"
+	"musicaltyper-go/game/logger"
+	"sync/atomic"
+	"time"
+
+	"github.com/veandco/go-sdl2/mix"
+)
+
+// MixerClock is Clock which follows how many bytes of music the mixer has played
+type MixerClock struct {
+	bytesPerSecond float64
+
+	// These are touched from audio thread, so access only via sync/atomic.
+	running      int32
+	mixedBytes   int64
+	lastMixBytes int64
+	lastMixTime  int64
+}
+
+// NewMixerClock makes MixerClock and hooks it to the mixer. The mixer must be opened before.
+func NewMixerClock() *MixerClock {
+	Logger := logger.NewLogger("NewMixerClock")
+
+	Frequency, Format, Channels, _, Err := mix.QuerySpec()
+	Logger.CheckError(Err)
+
+	//SDL_AUDIO_BITSIZE
+	BytesPerSample := int(Format&0xFF) / 8
+
+	Result := new(MixerClock)
+	Result.bytesPerSecond = float64(Frequency * Channels * BytesPerSample)
+	mix.SetPostMix(Result.onPostMix)
+	return Result
+}
+
+// called from audio thread. Never call SDL_mixer functions here.
+func (c *MixerClock) onPostMix(stream []uint8) {
+	if atomic.LoadInt32(&c.running) == 0 {
+		return
+	}
+	atomic.AddInt64(&c.mixedBytes, int64(len(stream)))
+	atomic.StoreInt64(&c.lastMixBytes, int64(len(stream)))
+	atomic.StoreInt64(&c.lastMixTime, time.Now().UnixNano())
+}
+
+// Start begins counting. Call this right after starting music.
+func (c *MixerClock) Start() {
+	atomic.StoreInt32(&c.running, 1)
+}
+
+// Pause stops counting. Call this right before pausing music.
+// Mixer hook stays, because the mixer can have only one.
+func (c *MixerClock) Pause() {
+	atomic.StoreInt32(&c.running, 0)
+}
+
+// Resume restarts counting. Call this right after resuming music.
+func (c *MixerClock) Resume() {
+	c.Start()
+}
+
+// Now returns playback position of music
+func (c *MixerClock) Now() float64 {
+	var (
+		MixedBytes   = atomic.LoadInt64(&c.mixedBytes)
+		LastMixBytes = atomic.LoadInt64(&c.lastMixBytes)
+		LastMixTime  = atomic.LoadInt64(&c.lastMixTime)
+	)
+	if MixedBytes == 0 {
+		return 0
+	}
+
+	//While stopped, every mixed buffer will have been played out.
+	if atomic.LoadInt32(&c.running) == 0 {
+		return float64(MixedBytes) / c.bytesPerSecond
+	}
+
+	//The last mixed buffer is being played now, so interpolate inside it by system time.
+	Played := float64(MixedBytes-LastMixBytes) / c.bytesPerSecond
+	BufferDuration := float64(LastMixBytes) / c.bytesPerSecond
+	SinceLastMix := time.Since(time.Unix(0, LastMixTime)).Seconds()
+	return Played + math.Min(SinceLastMix, BufferDuration)
+}
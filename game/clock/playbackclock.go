@@ -0,0 +1,69 @@
+package clock
+
+import (
+	"time"
+)
+
+// PlaybackClock is Clock driven by system time, which can be paused, seeked and scaled
+type PlaybackClock struct {
+	baseTime   time.Time
+	baseOffset float64
+	rate       float64
+	paused     bool
+}
+
+// NewPlaybackClock makes PlaybackClock running from zero with normal rate
+func NewPlaybackClock() *PlaybackClock {
+	return &PlaybackClock{
+		baseTime: time.Now(),
+		rate:     1,
+	}
+}
+
+// Now returns current position
+func (c *PlaybackClock) Now() float64 {
+	if c.paused {
+		return c.baseOffset
+	}
+	return c.baseOffset + time.Now().Sub(c.baseTime).Seconds()*c.rate
+}
+
+// rebase fixes current position as new base
+func (c *PlaybackClock) rebase() {
+	c.baseOffset = c.Now()
+	c.baseTime = time.Now()
+}
+
+// Pause stops clock
+func (c *PlaybackClock) Pause() {
+	c.rebase()
+	c.paused = true
+}
+
+// Resume restarts stopped clock
+func (c *PlaybackClock) Resume() {
+	c.baseTime = time.Now()
+	c.paused = false
+}
+
+// IsPaused returns whether clock is stopped
+func (c *PlaybackClock) IsPaused() bool {
+	return c.paused
+}
+
+// Seek moves current position
+func (c *PlaybackClock) Seek(Position float64) {
+	c.baseOffset = Position
+	c.baseTime = time.Now()
+}
+
+// SetRate changes how fast clock goes
+func (c *PlaybackClock) SetRate(Rate float64) {
+	c.rebase()
+	c.rate = Rate
+}
+
+// Rate returns how fast clock goes
+func (c *PlaybackClock) Rate() float64 {
+	return c.rate
+}
@@ -0,0 +1,38 @@
+package clock
+
+import (
+	"time"
+)
+
+type wallClock struct {
+	startTime time.Time
+	pausedAt  *time.Time
+}
+
+// NewWallClock makes Clock which counts system time elapsed from now
+func NewWallClock() Clock {
+	return &wallClock{
+		startTime: time.Now(),
+	}
+}
+
+func (c *wallClock) Now() float64 {
+	if c.pausedAt != nil {
+		return c.pausedAt.Sub(c.startTime).Seconds()
+	}
+	return time.Now().Sub(c.startTime).Seconds()
+}
+
+func (c *wallClock) Pause() {
+	if c.pausedAt == nil {
+		Now := time.Now()
+		c.pausedAt = &Now
+	}
+}
+
+func (c *wallClock) Resume() {
+	if c.pausedAt != nil {
+		c.startTime = c.startTime.Add(time.Now().Sub(*c.pausedAt))
+		c.pausedAt = nil
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"musicaltyper-go/game/logger"
+	"os"
+)
+
+const (
+	// DefaultPath is path of configuration file loaded on startup
+	DefaultPath = "config.json"
+)
+
+// Config has user settings. Every field has its default, so configuration file can omit any of them.
+type Config struct {
+	// ScoringRule is ID of scoring rule used unless beatmap specifies one
+	ScoringRule string `json:"scoring_rule"`
+
+	// LifeGauge is shape of life gauge: "off", "normal", "hard" or "sudden_death"
+	LifeGauge string `json:"life_gauge"`
+
+	// RankTables are rank tables defined by user, in addition to built-in "classic" and "simple"
+	RankTables map[string][]RankEntry `json:"rank_tables"`
+	// RuleRankTables maps scoring rule ID to rank table ID
+	RuleRankTables map[string]string `json:"rule_rank_tables"`
+
+	// HistoryPath is path of file where every finished play is saved
+	HistoryPath string `json:"history_path"`
+	// ExportDir is directory where exported results and image cards are saved
+	ExportDir string `json:"export_dir"`
+
+	// SongDirs are directories scanned for beatmaps to show in song select
+	SongDirs []string `json:"song_dirs"`
+	// LibraryCachePath is path of file where metadata of scanned beatmaps are cached
+	LibraryCachePath string `json:"library_cache_path"`
+}
+
+// RankEntry is single rank in rank table
+type RankEntry struct {
+	// Border is the lowest achievement rate in percent to get the rank
+	Border float64  `json:"border"`
+	Label  string   `json:"label"`
+	Color  [3]uint8 `json:"color"`
+}
+
+var current = Default()
+
+// Default makes Config with default values
+func Default() *Config {
+	return &Config{
+		ScoringRule: "classic",
+		LifeGauge:   "off",
+		RankTables:  map[string][]RankEntry{},
+		RuleRankTables: map[string]string{
+			"classic":  "classic",
+			"accuracy": "simple",
+		},
+		HistoryPath:      "history.jsonl",
+		ExportDir:        "export",
+		SongDirs:         []string{"."},
+		LibraryCachePath: "library.json",
+	}
+}
+
+// Load reads configuration file. If the file doesn't exist, defaults are used.
+func Load(Path string) {
+	Logger := logger.NewLogger("LoadConfig")
+
+	Data, Err := ioutil.ReadFile(Path)
+	if os.IsNotExist(Err) {
+		return
+	}
+	Logger.CheckError(Err)
+
+	Result := Default()
+	Logger.CheckError(json.Unmarshal(Data, Result))
+	current = Result
+}
+
+// Get returns current Config
+func Get() *Config {
+	return current
+}
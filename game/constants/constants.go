@@ -22,8 +22,13 @@ const (
 	PrintRomaJudgeCheck = false
 	Print
 
+	// ReplayDirectory is directory where replay of every play is saved
+	ReplayDirectory = "replays"
+
 	// AudioChannelNum is the number of will be allocated sound channels.
 	AudioChannelNum = 32
+	// MusicVolume is volume of song from 0 to 1
+	MusicVolume = 0.1
 
 	// OneCharPoint is point per typed correct
 	OneCharPoint = 10
@@ -75,6 +75,19 @@ func (c Color) Invert() Color {
 	}
 }
 
+// Mix makes new Color between c (ratio 0.0) and other (ratio 1.0)
+func (c Color) Mix(other Color, ratio float64) Color {
+	mix := func(a, b uint8) uint8 {
+		return clamp(int(float64(a) + (float64(b)-float64(a))*ratio))
+	}
+	return Color{
+		r: mix(c.r, other.r),
+		g: mix(c.g, other.g),
+		b: mix(c.b, other.b),
+		a: mix(c.a, other.a),
+	}
+}
+
 // ToSDLColor casts Color to sdl.Color
 func (c Color) ToSDLColor() *sdl.Color {
 	return &sdl.Color{
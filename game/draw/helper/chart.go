@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/color"
+	"musicaltyper-go/game/draw/pos"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Chart maps values onto an area of screen. Y grows upward unlike screen.
+type Chart struct {
+	Area area.Area
+
+	MinX, MaxX float64
+	MinY, MaxY float64
+}
+
+// Pos converts values into position on screen. Values out of range are clamped into the area.
+func (c Chart) Pos(X, Y float64) pos.Pos {
+	return pos.FromXY(
+		c.Area.X()+int(float64(c.Area.W())*ratio(X, c.MinX, c.MaxX)),
+		c.Area.Y()+c.Area.H()-int(float64(c.Area.H())*ratio(Y, c.MinY, c.MaxY)),
+	)
+}
+
+// DrawSeries draws a polyline through points of Xs and Ys
+func (c Chart) DrawSeries(Renderer *sdl.Renderer, Xs, Ys []float64, Color color.Color, Thickness int) {
+	Points := make([]pos.Pos, 0, len(Xs))
+	for i := range Xs {
+		Points = append(Points, c.Pos(Xs[i], Ys[i]))
+	}
+	DrawPolyline(Renderer, Points, Color, Thickness)
+}
+
+// DrawVerticalLine draws a line across the chart at X
+func (c Chart) DrawVerticalLine(Renderer *sdl.Renderer, X float64, Color color.Color) {
+	Top := c.Pos(X, c.MaxY)
+	Color.ApplyColor(Renderer)
+	Renderer.DrawLine(int32(Top.X()), int32(Top.Y()), int32(Top.X()), int32(c.Area.Y()+c.Area.H()))
+}
+
+// DrawHorizontalLine draws a line across the chart at Y
+func (c Chart) DrawHorizontalLine(Renderer *sdl.Renderer, Y float64, Color color.Color) {
+	Left := c.Pos(c.MinX, Y)
+	Color.ApplyColor(Renderer)
+	Renderer.DrawLine(int32(Left.X()), int32(Left.Y()), int32(c.Area.X()+c.Area.W()), int32(Left.Y()))
+}
+
+// DrawPolyline draws lines connecting points in order
+func DrawPolyline(Renderer *sdl.Renderer, Points []pos.Pos, Color color.Color, Thickness int) {
+	if len(Points) < 2 {
+		return
+	}
+	Color.ApplyColor(Renderer)
+
+	//SDL can't draw thick lines, so lines shifted vertically are drawn instead.
+	Lines := make([]sdl.Point, len(Points))
+	for t := 0; t < Thickness; t++ {
+		Offset := t - Thickness/2
+		for i, p := range Points {
+			Lines[i] = sdl.Point{X: int32(p.X()), Y: int32(p.Y() + Offset)}
+		}
+		Renderer.DrawLines(Lines)
+	}
+}
+
+// ratio returns where Value is between Min and Max, from 0 to 1
+func ratio(Value, Min, Max float64) float64 {
+	if Max <= Min {
+		return 0
+	}
+	Ratio := (Value - Min) / (Max - Min)
+	if Ratio < 0 {
+		return 0
+	}
+	if Ratio > 1 {
+		return 1
+	}
+	return Ratio
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"image"
+	"image/png"
+	"io"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+const (
+	// CardWidth and CardHeight are size of result image card
+	CardWidth  = constants.WindowWidth
+	CardHeight = 350
+)
+
+/*
+Q. Why not screenshot of the window?
+A. The window may be covered or scaled, and CLI has no window shown.
+   The card is drawn into a texture as render target, and its pixels are read back.
+*/
+
+// SaveCard draws a card by draw into an off-screen target of the renderer, and saves it as PNG
+func SaveCard(Renderer *sdl.Renderer, Path string, draw func(Renderer *sdl.Renderer)) error {
+	Target, Err := Renderer.CreateTexture(sdl.PIXELFORMAT_ABGR8888, sdl.TEXTUREACCESS_TARGET, CardWidth, CardHeight)
+	if Err != nil {
+		return Err
+	}
+	defer Target.Destroy()
+
+	Previous := Renderer.GetRenderTarget()
+	if Err := Renderer.SetRenderTarget(Target); Err != nil {
+		return Err
+	}
+	defer Renderer.SetRenderTarget(Previous)
+
+	draw(Renderer)
+
+	//ABGR8888 is R, G, B, A in order of bytes on little endian, the same as image.RGBA.
+	Image := image.NewRGBA(image.Rect(0, 0, CardWidth, CardHeight))
+	if Err := Renderer.ReadPixels(nil, sdl.PIXELFORMAT_ABGR8888, unsafe.Pointer(&Image.Pix[0]), Image.Stride); Err != nil {
+		return Err
+	}
+	//Background is cleared transparent, but the card should be opaque.
+	for i := 3; i < len(Image.Pix); i += 4 {
+		Image.Pix[i] = 255
+	}
+
+	return writeFile(Path, func(w io.Writer) error {
+		return png.Encode(w, Image)
+	})
+}
+
+// NewHeadlessRenderer makes renderer of hidden window, to draw cards without starting game. close releases it.
+func NewHeadlessRenderer() (Renderer *sdl.Renderer, close func(), Err error) {
+	if Err = sdl.Init(sdl.INIT_VIDEO); Err != nil {
+		return nil, nil, Err
+	}
+	if Err = ttf.Init(); Err != nil {
+		sdl.Quit()
+		return nil, nil, Err
+	}
+
+	Window, Err := sdl.CreateWindow(constants.WindowTitle, sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED, CardWidth, CardHeight, sdl.WINDOW_HIDDEN)
+	if Err != nil {
+		ttf.Quit()
+		sdl.Quit()
+		return nil, nil, Err
+	}
+
+	Renderer, Err = sdl.CreateRenderer(Window, -1, sdl.RENDERER_TARGETTEXTURE)
+	if Err != nil {
+		Window.Destroy()
+		ttf.Quit()
+		sdl.Quit()
+		return nil, nil, Err
+	}
+
+	return Renderer, func() {
+		helper.Quit()
+		Renderer.Destroy()
+		Window.Destroy()
+		ttf.Quit()
+		sdl.Quit()
+	}, nil
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/judge"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Play is a finished play in the form to share and analyse
+type Play struct {
+	Title      string `json:"title"`
+	Artist     string `json:"artist,omitempty"`
+	Chart      string `json:"chart,omitempty"`
+	ChartID    string `json:"chart_id"`
+	MapHash    string `json:"map_hash"`
+	MapVersion string `json:"map_version,omitempty"`
+
+	RuleID   string  `json:"rule"`
+	Mods     string  `json:"mods"`
+	Rate     float64 `json:"rate"`
+	Practice bool    `json:"practice"`
+	Autoplay bool    `json:"autoplay"`
+
+	Point     int     `json:"point"`
+	Accuracy  float64 `json:"accuracy"`
+	TypeSpeed float64 `json:"type_speed"`
+	// Reaction, IntervalSpread and TypingKPS are rhythm of the play. Plays from history don't have them.
+	Reaction        float64 `json:"reaction,omitempty"`
+	IntervalSpread  float64 `json:"interval_spread,omitempty"`
+	TypingKPS       float64 `json:"typing_kps,omitempty"`
+	AchievementRate float64 `json:"achievement_rate"`
+	Rank            string  `json:"rank"`
+	Failed          bool    `json:"failed"`
+	// Mistakes is confusion matrix of the play made by mistake.Matrix.ToMap, e.g. {"a>s": 2}
+	Mistakes map[string]int `json:"mistakes,omitempty"`
+
+	PlayedAt time.Time `json:"played_at"`
+
+	// Sentences are how each sentence was typed. Plays from history don't have them.
+	Sentences []Sentence `json:"sentences,omitempty"`
+}
+
+// Sentence is how a sentence was typed
+type Sentence struct {
+	NoteIndex int     `json:"note"`
+	Lyric     string  `json:"lyric"`
+	Judge     string  `json:"judge"`
+	MissCount int     `json:"miss"`
+	Typed     string  `json:"typed"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+	Duration  float64 `json:"duration"`
+	KPS       float64 `json:"kps"`
+	Reaction  float64 `json:"reaction"`
+}
+
+var (
+	playColumns     = []string{"played_at", "title", "artist", "chart_id", "map_hash", "map_version", "rule", "mods", "rate", "practice", "autoplay", "point", "accuracy", "type_speed", "reaction", "interval_spread", "typing_kps", "achievement_rate", "rank", "failed", "mistakes"}
+	sentenceColumns = []string{"note", "lyric", "judge", "miss", "typed", "start_time", "end_time", "duration", "kps", "reaction"}
+)
+
+// FromSentences converts sentence results into exported form
+func FromSentences(Sentences []judge.SentenceResult) []Sentence {
+	Result := make([]Sentence, 0, len(Sentences))
+	for _, v := range Sentences {
+		Result = append(Result, Sentence{
+			NoteIndex: v.NoteIndex,
+			Lyric:     v.Lyric,
+			Judge:     v.Judge.String(),
+			MissCount: v.MissCount,
+			Typed:     v.Typed,
+			StartTime: v.StartTime,
+			EndTime:   v.EndTime,
+			Duration:  v.Duration,
+			KPS:       v.KPS,
+			Reaction:  v.Reaction,
+		})
+	}
+	return Result
+}
+
+// FromRecord makes Play from a play in history
+func FromRecord(Record history.Record) Play {
+	return Play{
+		Title:           Record.MapTitle,
+		Chart:           Record.Chart,
+		ChartID:         Record.ChartID,
+		MapHash:         Record.MapHash,
+		MapVersion:      Record.MapVersion,
+		RuleID:          Record.RuleID,
+		Mods:            Record.Mods.String(),
+		Rate:            Record.Rate,
+		Point:           Record.Point,
+		Accuracy:        Record.Accuracy,
+		TypeSpeed:       Record.TypeSpeed,
+		AchievementRate: Record.AchievementRate,
+		Rank:            Record.Rank,
+		Failed:          Record.Failed,
+		Mistakes:        Record.Mistakes,
+		PlayedAt:        Record.PlayedAt,
+	}
+}
+
+// BaseName returns file name for the play without extension, e.g. 20200102-150405_title
+func BaseName(p Play) string {
+	Title := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>| `, r) {
+			return '_'
+		}
+		return r
+	}, p.Title)
+	return p.PlayedAt.Format("20060102-150405") + "_" + Title
+}
+
+// WriteJSON writes any of Play, or plays, into the file as indented JSON
+func WriteJSON(Path string, Value interface{}) error {
+	return writeFile(Path, func(w io.Writer) error {
+		Encoder := json.NewEncoder(w)
+		Encoder.SetIndent("", "  ")
+		return Encoder.Encode(Value)
+	})
+}
+
+// WritePlaysCSV writes summary of plays, a play in a row
+func WritePlaysCSV(Path string, Plays []Play) error {
+	return writeFile(Path, func(w io.Writer) error {
+		Writer := csv.NewWriter(w)
+		Writer.Write(playColumns)
+		for _, p := range Plays {
+			Writer.Write([]string{
+				p.PlayedAt.Format(time.RFC3339), p.Title, p.Artist, p.ChartID, p.MapHash, p.MapVersion,
+				p.RuleID, p.Mods, fmt.Sprint(p.Rate), fmt.Sprint(p.Practice), fmt.Sprint(p.Autoplay),
+				fmt.Sprint(p.Point), fmt.Sprint(p.Accuracy), fmt.Sprint(p.TypeSpeed),
+				fmt.Sprint(p.Reaction), fmt.Sprint(p.IntervalSpread), fmt.Sprint(p.TypingKPS), fmt.Sprint(p.AchievementRate),
+				p.Rank, fmt.Sprint(p.Failed), formatMistakes(p.Mistakes),
+			})
+		}
+		Writer.Flush()
+		return Writer.Error()
+	})
+}
+
+// WriteSentencesCSV writes how each sentence of the play was typed, a sentence in a row
+func WriteSentencesCSV(Path string, p Play) error {
+	return writeFile(Path, func(w io.Writer) error {
+		Writer := csv.NewWriter(w)
+		Writer.Write(sentenceColumns)
+		for _, s := range p.Sentences {
+			Writer.Write([]string{
+				fmt.Sprint(s.NoteIndex), s.Lyric, s.Judge, fmt.Sprint(s.MissCount), s.Typed,
+				fmt.Sprint(s.StartTime), fmt.Sprint(s.EndTime), fmt.Sprint(s.Duration), fmt.Sprint(s.KPS), fmt.Sprint(s.Reaction),
+			})
+		}
+		Writer.Flush()
+		return Writer.Error()
+	})
+}
+
+// formatMistakes puts mistakes into a cell, most frequent first, e.g. "a>s:2 i>o:1"
+func formatMistakes(Mistakes map[string]int) string {
+	Keys := make([]string, 0, len(Mistakes))
+	for k := range Mistakes {
+		Keys = append(Keys, k)
+	}
+	sort.Slice(Keys, func(i, j int) bool {
+		if Mistakes[Keys[i]] != Mistakes[Keys[j]] {
+			return Mistakes[Keys[i]] > Mistakes[Keys[j]]
+		}
+		return Keys[i] < Keys[j]
+	})
+
+	Cells := make([]string, len(Keys))
+	for i, k := range Keys {
+		Cells[i] = fmt.Sprintf("%s:%d", k, Mistakes[k])
+	}
+	return strings.Join(Cells, " ")
+}
+
+// writeFile creates the file and its directory, and writes by write
+func writeFile(Path string, write func(w io.Writer) error) error {
+	if Err := os.MkdirAll(filepath.Dir(Path), 0755); Err != nil {
+		return Err
+	}
+	File, Err := os.Create(Path)
+	if Err != nil {
+		return Err
+	}
+	if Err := write(File); Err != nil {
+		File.Close()
+		return Err
+	}
+	return File.Close()
+}
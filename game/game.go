@@ -2,20 +2,19 @@ package game
 
 import (
 	"fmt"
-	"musicaltyper-go/game/beatmap"
 	"musicaltyper-go/game/constants"
 	"musicaltyper-go/game/draw/helper"
 	"musicaltyper-go/game/logger"
 	"musicaltyper-go/game/view"
-	mainview "musicaltyper-go/game/view/game"
 
 	"github.com/veandco/go-sdl2/mix"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
 
-// Run runs game with beatmap
-func Run(beatmap *beatmap.Beatmap) {
+// Run runs game from the view made by InitialView. It is called after audio and video are ready.
+// The game ends when the last view is popped.
+func Run(InitialView func() view.View) {
 	Logger := logger.NewLogger("GameRun")
 
 	Logger.CheckError(sdl.Init(sdl.INIT_VIDEO))
@@ -54,29 +53,33 @@ func Run(beatmap *beatmap.Beatmap) {
 
 	fmt.Println("DrawStart")
 
-	var (
-		CurrentView = mainview.NewMainView(beatmap)
-		Running     = true
-	)
+	Stack := view.Stack{}
+	Stack.Push(InitialView())
+	fmt.Println("Changed view to", Stack.Top().GetName())
+	//Views must free their music before the mixer is closed.
+	defer Stack.Clear()
 
-	for Running {
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+	for Running := true; Running && !Stack.IsEmpty(); {
+		for event := sdl.PollEvent(); event != nil && Running; event = sdl.PollEvent() {
 			switch event.(type) {
 			case *sdl.QuitEvent:
 				Running = false
 			default:
-				Running = CurrentView.HandleSDLEvent(Renderer, event)
+				Running = Stack.Top().HandleSDLEvent(Renderer, event)
 			}
 		}
+		if !Running {
+			break
+		}
 
-		CurrentView.Draw(Renderer)
+		Stack.Top().Draw(Renderer)
 
-		for event := CurrentView.PollEvent(); event != nil; event = CurrentView.PollEvent() {
-			switch ev := event.(type) {
-			case *view.ChangeViewEvent:
-				CurrentView = ev.ToChangeView
-				fmt.Println("Changed view to", CurrentView.GetName())
+		for event := Stack.Top().PollEvent(); event != nil; event = Stack.Top().PollEvent() {
+			Stack.Apply(event)
+			if Stack.IsEmpty() {
+				break
 			}
+			fmt.Println("Changed view to", Stack.Top().GetName())
 		}
 
 		sdl.Delay(1000 / constants.FrameRate)
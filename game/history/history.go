@@ -0,0 +1,106 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/mods"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record is a finished play
+type Record struct {
+	// MapHash is fingerprint of the beatmap
+	MapHash string `json:"map_hash"`
+	// ChartID and MapVersion are identity of the chart across its versions
+	ChartID    string `json:"chart_id"`
+	MapVersion string `json:"map_version"`
+	MapTitle   string `json:"map_title"`
+	// Chart is path of the beatmap file when played
+	Chart string `json:"chart"`
+
+	RuleID string   `json:"rule"`
+	Mods   mods.Mod `json:"mods"`
+	Rate   float64  `json:"rate"`
+
+	Point           int     `json:"point"`
+	Accuracy        float64 `json:"accuracy"`
+	TypeSpeed       float64 `json:"type_speed"`
+	AchievementRate float64 `json:"achievement_rate"`
+	Rank            string  `json:"rank"`
+	Failed          bool    `json:"failed"`
+
+	// Mistakes is confusion matrix of the play made by mistake.Matrix.ToMap
+	Mistakes map[string]int `json:"mistakes,omitempty"`
+	// Replay is path of replay file of the play, or empty if not saved
+	Replay string `json:"replay,omitempty"`
+
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// Store keeps every play in append-only JSON lines file
+type Store struct {
+	path    string
+	records []Record
+}
+
+// Open reads history file. If the file doesn't exist, Store is empty until a play is added.
+func Open(Path string) (*Store, error) {
+	Logger := logger.NewLogger("OpenHistory")
+	Result := &Store{
+		path:    Path,
+		records: make([]Record, 0),
+	}
+
+	File, Err := os.Open(Path)
+	if os.IsNotExist(Err) {
+		return Result, nil
+	}
+	if Err != nil {
+		return nil, Err
+	}
+	defer File.Close()
+
+	Scanner := bufio.NewScanner(File)
+	for Scanner.Scan() {
+		if len(Scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var Record Record
+		if Err := json.Unmarshal(Scanner.Bytes(), &Record); Err != nil {
+			//A line may be broken by crash while writing. Others are still usable.
+			Logger.Warn("Skipped broken history record: " + Err.Error())
+			continue
+		}
+		Result.records = append(Result.records, Record)
+	}
+	return Result, Scanner.Err()
+}
+
+// Add appends the play to history file
+func (s *Store) Add(Record Record) error {
+	Line, Err := json.Marshal(Record)
+	if Err != nil {
+		return Err
+	}
+
+	if Dir := filepath.Dir(s.path); Dir != "." {
+		if Err := os.MkdirAll(Dir, 0755); Err != nil {
+			return Err
+		}
+	}
+	File, Err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if Err != nil {
+		return Err
+	}
+	defer File.Close()
+
+	if _, Err := File.Write(append(Line, '\n')); Err != nil {
+		return Err
+	}
+	s.records = append(s.records, Record)
+	return nil
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"musicaltyper-go/game/mistake"
+	"musicaltyper-go/game/mods"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Records returns every play in order of being added
+func (s *Store) Records() []Record {
+	return s.records
+}
+
+// ForChart returns plays of the beatmap in order of being added
+func (s *Store) ForChart(MapHash string) []Record {
+	Result := make([]Record, 0)
+	for _, v := range s.records {
+		if v.MapHash == MapHash {
+			Result = append(Result, v)
+		}
+	}
+	return Result
+}
+
+// OlderVersions returns plays of versions of the chart older than the one of MapHash. They must not be mixed with plays of it.
+// Versions are ordered by when they were played first. Plays recorded before ChartID existed are matched by path of the beatmap file.
+func (s *Store) OlderVersions(ChartID, MapHash, Chart string) []Record {
+	Since := time.Now()
+	for _, v := range s.records {
+		if v.MapHash == MapHash && v.PlayedAt.Before(Since) {
+			Since = v.PlayedAt
+		}
+	}
+
+	Result := make([]Record, 0)
+	for _, v := range s.records {
+		if v.MapHash != MapHash && v.PlayedAt.Before(Since) && v.isOfChart(ChartID, Chart) {
+			Result = append(Result, v)
+		}
+	}
+	return Result
+}
+
+// isOfChart returns whether the play is of the chart in any version
+func (r Record) isOfChart(ChartID, Chart string) bool {
+	if r.ChartID != "" {
+		return r.ChartID == ChartID
+	}
+	return r.Chart != "" && isSamePath(r.Chart, Chart)
+}
+
+func isSamePath(a, b string) bool {
+	A, ErrA := filepath.Abs(a)
+	B, ErrB := filepath.Abs(b)
+	if ErrA != nil || ErrB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return A == B
+}
+
+// PersonalBest returns the best cleared play of the beatmap with the scoring rule, playback rate and mods.
+// Plays with different rules, rates or mods are not comparable, so they are not mixed.
+func (s *Store) PersonalBest(MapHash, RuleID string, Rate float64, Mods mods.Mod) (Record, bool) {
+	return s.best(MapHash, RuleID, Rate, Mods, func(Record) bool { return true })
+}
+
+// BestReplay returns the best cleared play like PersonalBest, among plays whose replay was saved
+func (s *Store) BestReplay(MapHash, RuleID string, Rate float64, Mods mods.Mod) (Record, bool) {
+	return s.best(MapHash, RuleID, Rate, Mods, func(v Record) bool { return v.Replay != "" })
+}
+
+func (s *Store) best(MapHash, RuleID string, Rate float64, Mods mods.Mod, Filter func(Record) bool) (Record, bool) {
+	var (
+		Best  Record
+		Found = false
+	)
+	for _, v := range s.ForChart(MapHash) {
+		if !v.isPlayedWith(RuleID, Rate, Mods) || v.Failed || !Filter(v) {
+			continue
+		}
+		if !Found || v.IsBetterThan(Best) {
+			Best = v
+			Found = true
+		}
+	}
+	return Best, Found
+}
+
+// isPlayedWith returns whether the play was played with the scoring rule, playback rate and mods
+func (r Record) isPlayedWith(RuleID string, Rate float64, Mods mods.Mod) bool {
+	return r.RuleID == RuleID && r.Rate == Rate && r.Mods == Mods
+}
+
+// Recent returns the latest plays up to Count, newest first
+func (s *Store) Recent(Count int) []Record {
+	Result := make([]Record, len(s.records))
+	copy(Result, s.records)
+	sort.SliceStable(Result, func(i, j int) bool {
+		return Result[i].PlayedAt.After(Result[j].PlayedAt)
+	})
+
+	if len(Result) > Count {
+		Result = Result[:Count]
+	}
+	return Result
+}
+
+// Trend returns achievement rates of the latest plays of the beatmap with the rule, playback rate and mods up to Count, oldest first
+func (s *Store) Trend(MapHash, RuleID string, Rate float64, Mods mods.Mod, Count int) []float64 {
+	Result := make([]float64, 0)
+	for _, v := range s.ForChart(MapHash) {
+		if v.isPlayedWith(RuleID, Rate, Mods) && !v.Failed {
+			Result = append(Result, v.AchievementRate)
+		}
+	}
+
+	if len(Result) > Count {
+		Result = Result[len(Result)-Count:]
+	}
+	return Result
+}
+
+// Mistakes sums up mistakes of every play, to show which keys need practice over time
+func (s *Store) Mistakes() *mistake.Matrix {
+	Result := mistake.NewMatrix()
+	for _, v := range s.records {
+		Result.Add(mistake.FromMap(v.Mistakes))
+	}
+	return Result
+}
+
+// IsBetterThan returns whether the play is better than other. Achievement rate is compared first, then point.
+// Both must be played at the same rate and mods, since achievement rate isn't scaled by them.
+func (r Record) IsBetterThan(Other Record) bool {
+	if r.AchievementRate != Other.AchievementRate {
+		return r.AchievementRate > Other.AchievementRate
+	}
+	return r.Point > Other.Point
+}
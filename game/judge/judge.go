@@ -0,0 +1,47 @@
+package judge
+
+// Judge is a kind of judgement for single key input
+type Judge uint8
+
+const (
+	// IGNORED means the key is not for typing
+	IGNORED Judge = iota
+	// UNNECESSARY means the key was typed while input is disabled
+	UNNECESSARY
+	// CORRECT means the key was expected one
+	CORRECT
+	// MISS means the key was not expected one
+	MISS
+	// AC means the key was correct and finished sentence without any miss
+	AC
+	// WA means the key was correct and finished sentence with some misses
+	WA
+	// TLE means the sentence wasn't finished in time. It's not for a key but for a sentence.
+	TLE
+)
+
+// IsCorrect returns whether the key was accepted
+func (j Judge) IsCorrect() bool {
+	return j == CORRECT || j == AC || j == WA
+}
+
+func (j Judge) String() string {
+	switch j {
+	case IGNORED:
+		return "Ignored"
+	case UNNECESSARY:
+		return "Unnecessary"
+	case CORRECT:
+		return "Correct"
+	case MISS:
+		return "Miss"
+	case AC:
+		return "AC"
+	case WA:
+		return "WA"
+	case TLE:
+		return "TLE"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,25 @@
+package judge
+
+// SentenceResult is how a sentence was typed
+type SentenceResult struct {
+	// NoteIndex is index of the note in the beatmap
+	NoteIndex int
+	Lyric     string
+
+	// Judge is AC, WA or TLE
+	Judge Judge
+	// MissCount is count of mistyped keys. Keys left untyped by TLE aren't included.
+	MissCount int
+	// Typed is romaji the player actually typed
+	Typed string
+
+	// StartTime is song time when the sentence began, and EndTime is when it was finished or timed out
+	StartTime float64
+	EndTime   float64
+
+	// Duration is seconds taken to type, and KPS is typing speed in it. Both are in real time, not in song time.
+	Duration float64
+	KPS      float64
+	// Reaction is seconds from beginning of the sentence to its first key in real time. 0 if no key was typed.
+	Reaction float64
+}
@@ -0,0 +1,276 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/logger"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Extension is extension of beatmap files
+	Extension = ".tsc"
+)
+
+// Song is metadata of a beatmap file in the library
+type Song struct {
+	Path string `json:"path"`
+	// ModTime and Size tell whether the cached metadata is still fresh
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+
+	Title       string `json:"title"`
+	SongAuthor  string `json:"song_author"`
+	Singer      string `json:"singer"`
+	ScoreAuthor string `json:"score_author"`
+	// Difficulty is difficulty property written by the score author, which may be empty
+	Difficulty string `json:"difficulty,omitempty"`
+	// KPS is average typing speed needed to type every sentence in time
+	KPS float64 `json:"kps"`
+	// Length is song time of the end of the chart in seconds
+	Length      float64 `json:"length"`
+	SongData    string  `json:"song_data"`
+	ScoringRule string  `json:"scoring_rule,omitempty"`
+
+	MapHash string `json:"map_hash"`
+	ChartID string `json:"chart_id"`
+}
+
+// Artist returns song author and singer
+func (s Song) Artist() string {
+	switch {
+	case s.SongAuthor != "" && s.Singer != "":
+		return s.SongAuthor + "/" + s.Singer
+	case s.SongAuthor != "":
+		return s.SongAuthor
+	default:
+		return s.Singer
+	}
+}
+
+// Library is songs found in directories, with their metadata cached in a file
+type Library struct {
+	mutex sync.Mutex
+
+	dirs      []string
+	cachePath string
+	songs     []Song
+	// version increases whenever songs change
+	version int
+	// broken are modified time of files failed to load, not to load them again until modified
+	broken map[string]time.Time
+}
+
+// Open reads cached metadata, and scans directories for changed songs
+func Open(Dirs []string, CachePath string) (*Library, error) {
+	Logger := logger.NewLogger("OpenLibrary")
+	Result := &Library{
+		dirs:      Dirs,
+		cachePath: CachePath,
+		songs:     make([]Song, 0),
+		broken:    map[string]time.Time{},
+	}
+
+	Data, Err := ioutil.ReadFile(CachePath)
+	switch {
+	case os.IsNotExist(Err):
+	case Err != nil:
+		return nil, Err
+	default:
+		if Err := json.Unmarshal(Data, &Result.songs); Err != nil {
+			//Cache is only for speed. Everything is scanned again.
+			Logger.Warn("Ignored broken library cache: " + Err.Error())
+			Result.songs = make([]Song, 0)
+		}
+	}
+
+	if _, Err := Result.Refresh(); Err != nil {
+		return nil, Err
+	}
+	return Result, nil
+}
+
+// Songs returns every song in the library
+func (l *Library) Songs() []Song {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	Result := make([]Song, len(l.songs))
+	copy(Result, l.songs)
+	return Result
+}
+
+// Version returns a number which changes whenever songs change
+func (l *Library) Version() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.version
+}
+
+// Refresh scans directories again, and returns whether any song has been added, changed or removed.
+// Only new or modified files are parsed. It must not run concurrently with itself.
+func (l *Library) Refresh() (bool, error) {
+	Logger := logger.NewLogger("RefreshLibrary")
+
+	Cached := map[string]Song{}
+	for _, v := range l.Songs() {
+		Cached[v.Path] = v
+	}
+
+	var (
+		Songs   = make([]Song, 0, len(Cached))
+		Changed = false
+	)
+	for _, Dir := range l.dirs {
+		Err := filepath.Walk(Dir, func(Path string, Info os.FileInfo, Err error) error {
+			if Err != nil {
+				return Err
+			}
+			if Info.IsDir() || !strings.EqualFold(filepath.Ext(Path), Extension) {
+				return nil
+			}
+
+			if Song, Exists := Cached[Path]; Exists && Song.ModTime.Equal(Info.ModTime()) && Song.Size == Info.Size() {
+				Songs = append(Songs, Song)
+				delete(Cached, Path)
+				return nil
+			}
+
+			if ModTime, Exists := l.broken[Path]; Exists && ModTime.Equal(Info.ModTime()) {
+				return nil
+			}
+
+			Song, Err := loadSong(Path, Info)
+			if Err != nil {
+				//A broken beatmap shouldn't hide the others.
+				Logger.Warn(fmt.Sprintf("Skipped %s: %s", Path, Err))
+				l.broken[Path] = Info.ModTime()
+				return nil
+			}
+			Songs = append(Songs, Song)
+			delete(Cached, Path)
+			Changed = true
+			return nil
+		})
+		if Err != nil && !os.IsNotExist(Err) {
+			return false, Err
+		}
+	}
+
+	//Songs left in cache have been removed.
+	if len(Cached) > 0 {
+		Changed = true
+	}
+	if !Changed {
+		return false, nil
+	}
+
+	l.mutex.Lock()
+	l.songs = Songs
+	l.version++
+	l.mutex.Unlock()
+
+	return true, l.save()
+}
+
+// Watch refreshes the library at intervals until stop is called
+func (l *Library) Watch(Interval time.Duration) (stop func()) {
+	Logger := logger.NewLogger("WatchLibrary")
+	var (
+		Ticker = time.NewTicker(Interval)
+		Done   = make(chan struct{})
+	)
+
+	go func() {
+		for {
+			select {
+			case <-Done:
+				return
+			case <-Ticker.C:
+				if _, Err := l.Refresh(); Err != nil {
+					Logger.Warn("Failed to refresh library: " + Err.Error())
+				}
+			}
+		}
+	}()
+
+	return func() {
+		Ticker.Stop()
+		close(Done)
+	}
+}
+
+// save writes metadata of songs into cache file
+func (l *Library) save() error {
+	Data, Err := json.MarshalIndent(l.Songs(), "", "  ")
+	if Err != nil {
+		return Err
+	}
+	if Err := os.MkdirAll(filepath.Dir(l.cachePath), 0755); Err != nil {
+		return Err
+	}
+	return ioutil.WriteFile(l.cachePath, Data, 0644)
+}
+
+// LoadMap loads beatmap, and returns error instead of exiting if the beatmap is broken
+func LoadMap(Path string) (Map *beatmap.Beatmap, Err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			Map = nil
+			Err = fmt.Errorf("failed to load beatmap: %v", r)
+		}
+	}()
+	return beatmap.LoadMap(Path), nil
+}
+
+// loadSong parses the beatmap to make its metadata
+func loadSong(Path string, Info os.FileInfo) (Song, error) {
+	Map, Err := LoadMap(Path)
+	if Err != nil {
+		return Song{}, Err
+	}
+
+	var (
+		Length     = 0.0
+		TotalKeys  = 0
+		TotalTime  = 0.0
+		Properties = Map.Properties
+	)
+	if len(Map.Notes) > 0 {
+		Length = Map.Notes[len(Map.Notes)-1].Time
+	}
+	for i, Note := range Map.Notes {
+		if Note.Type != beatmap.NORMAL || i+1 >= len(Map.Notes) {
+			continue
+		}
+		TotalKeys += len(Note.Sentence.GetShortestRemainingRoma())
+		TotalTime += Map.Notes[i+1].Time - Note.Time
+	}
+
+	Result := Song{
+		Path:        Path,
+		ModTime:     Info.ModTime(),
+		Size:        Info.Size(),
+		Title:       Properties["title"],
+		SongAuthor:  Properties["song_author"],
+		Singer:      Properties["singer"],
+		ScoreAuthor: Properties["score_author"],
+		Difficulty:  Properties["difficulty"],
+		Length:      Length,
+		SongData:    Properties["song_data"],
+		ScoringRule: Properties["scoring_rule"],
+		MapHash:     Map.Fingerprint,
+		ChartID:     Map.ChartID,
+	}
+	if TotalTime > 0 {
+		Result.KPS = float64(TotalKeys) / TotalTime
+	}
+	return Result, nil
+}
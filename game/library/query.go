@@ -0,0 +1,86 @@
+package library
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortKey is order of songs
+type SortKey uint8
+
+const (
+	// ByTitle sorts by title
+	ByTitle SortKey = iota
+	// ByArtist sorts by song author and singer
+	ByArtist
+	// ByDifficulty sorts from the easiest by typing speed needed
+	ByDifficulty
+	// ByLength sorts from the shortest
+	ByLength
+	// ByBest sorts from the highest personal best
+	ByBest
+
+	sortKeyCount
+)
+
+// Next returns the sort key after this, looping to the first
+func (k SortKey) Next() SortKey {
+	return (k + 1) % sortKeyCount
+}
+
+func (k SortKey) String() string {
+	switch k {
+	case ByTitle:
+		return "タイトル"
+	case ByArtist:
+		return "アーティスト"
+	case ByDifficulty:
+		return "難易度"
+	case ByLength:
+		return "長さ"
+	case ByBest:
+		return "自己ベスト"
+	default:
+		return "Unknown"
+	}
+}
+
+// Search returns songs whose title, artist or score author contains every word of Query, ignoring case
+func Search(Songs []Song, Query string) []Song {
+	Words := strings.Fields(strings.ToLower(Query))
+	Result := make([]Song, 0, len(Songs))
+
+	for _, v := range Songs {
+		Text := strings.ToLower(strings.Join([]string{v.Title, v.SongAuthor, v.Singer, v.ScoreAuthor}, " "))
+		Matched := true
+		for _, Word := range Words {
+			if !strings.Contains(Text, Word) {
+				Matched = false
+				break
+			}
+		}
+		if Matched {
+			Result = append(Result, v)
+		}
+	}
+	return Result
+}
+
+// Sort sorts songs by the key. Bests are personal best achievement rates by map hash, used by ByBest.
+func Sort(Songs []Song, Key SortKey, Bests map[string]float64) {
+	sort.SliceStable(Songs, func(i, j int) bool {
+		a, b := Songs[i], Songs[j]
+		switch Key {
+		case ByArtist:
+			return a.Artist() < b.Artist()
+		case ByDifficulty:
+			return a.KPS < b.KPS
+		case ByLength:
+			return a.Length < b.Length
+		case ByBest:
+			return Bests[a.MapHash] > Bests[b.MapHash]
+		default:
+			return a.Title < b.Title
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package life
+
+import (
+	"fmt"
+	"math"
+	"musicaltyper-go/game/logger"
+)
+
+// Shape is a kind of life gauge which decides how much life moves
+type Shape string
+
+const (
+	// OFF means no life gauge, the game never fails
+	OFF Shape = "off"
+	// NORMAL drains moderately and refills well
+	NORMAL Shape = "normal"
+	// HARD drains twice as fast and refills slowly
+	HARD Shape = "hard"
+	// SUDDENDEATH fails on the first miss
+	SUDDENDEATH Shape = "sudden_death"
+)
+
+type shapeParams struct {
+	missDrain    float64
+	tleCharDrain float64
+	correctGain  float64
+	acGain       float64
+}
+
+var (
+	shapes = map[Shape]shapeParams{
+		NORMAL:      {missDrain: 0.04, tleCharDrain: 0.01, correctGain: 0.002, acGain: 0.03},
+		HARD:        {missDrain: 0.08, tleCharDrain: 0.02, correctGain: 0.001, acGain: 0.015},
+		SUDDENDEATH: {missDrain: 1, tleCharDrain: 1, correctGain: 0, acGain: 0},
+	}
+)
+
+// Gauge has remaining life from 0 (failed) to 1 (full)
+type Gauge struct {
+	shape  Shape
+	params shapeParams
+	value  float64
+}
+
+// NewGauge makes full Gauge, or returns nil if shape is OFF or unknown
+func NewGauge(Shape Shape) *Gauge {
+	Params, Exists := shapes[Shape]
+	if !Exists {
+		if Shape != OFF {
+			Logger := logger.NewLogger("LifeGauge")
+			Logger.Warn(fmt.Sprintf("Unknown life gauge \"%s\". Playing without life gauge.", Shape))
+		}
+		return nil
+	}
+
+	return &Gauge{
+		shape:  Shape,
+		params: Params,
+		value:  1,
+	}
+}
+
+func (g *Gauge) add(delta float64) {
+	if g.IsEmpty() {
+		return
+	}
+	g.value = math.Max(0, math.Min(1, g.value+delta))
+}
+
+// OnMiss drains life by miss type
+func (g *Gauge) OnMiss() {
+	g.add(-g.params.missDrain)
+}
+
+// OnTLE drains life by characters couldn't be typed
+func (g *Gauge) OnTLE(RemainingLength int) {
+	if RemainingLength > 0 {
+		g.add(-g.params.tleCharDrain * float64(RemainingLength))
+	}
+}
+
+// OnCorrect refills life by correct type
+func (g *Gauge) OnCorrect() {
+	g.add(g.params.correctGain)
+}
+
+// OnAC refills life by sentence typed without any miss
+func (g *Gauge) OnAC() {
+	g.add(g.params.acGain)
+}
+
+// Value returns remaining life
+func (g *Gauge) Value() float64 {
+	return g.value
+}
+
+// IsEmpty returns whether the player failed
+func (g *Gauge) IsEmpty() bool {
+	return g.value <= 0
+}
+
+// Shape returns kind of the gauge
+func (g *Gauge) Shape() Shape {
+	return g.shape
+}
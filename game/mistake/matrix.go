@@ -0,0 +1,105 @@
+package mistake
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pair is a mistake of typing Typed where Expected is required
+type Pair struct {
+	Expected byte
+	Typed    byte
+}
+
+// PairCount is Pair with how many times it happened
+type PairCount struct {
+	Pair
+	Count int
+}
+
+// Matrix counts mistakes by expected and typed keys, known as confusion matrix
+type Matrix struct {
+	counts map[Pair]int
+	total  int
+}
+
+// NewMatrix makes empty Matrix
+func NewMatrix() *Matrix {
+	return &Matrix{
+		counts: map[Pair]int{},
+	}
+}
+
+// Record counts a mistake
+func (m *Matrix) Record(Expected, Typed byte) {
+	m.counts[Pair{Expected, Typed}]++
+	m.total++
+}
+
+// Add counts all mistakes of other Matrix
+func (m *Matrix) Add(Other *Matrix) {
+	for k, v := range Other.counts {
+		m.counts[k] += v
+		m.total += v
+	}
+}
+
+// Total returns how many mistakes are recorded
+func (m *Matrix) Total() int {
+	return m.total
+}
+
+// MissesOf returns how many times mistyped where the key is expected
+func (m *Matrix) MissesOf(Expected byte) int {
+	Result := 0
+	for k, v := range m.counts {
+		if k.Expected == Expected {
+			Result += v
+		}
+	}
+	return Result
+}
+
+// MostConfused returns pairs up to Count which happened most
+func (m *Matrix) MostConfused(Count int) []PairCount {
+	Result := make([]PairCount, 0, len(m.counts))
+	for k, v := range m.counts {
+		Result = append(Result, PairCount{k, v})
+	}
+	sort.Slice(Result, func(i, j int) bool {
+		if Result[i].Count != Result[j].Count {
+			return Result[i].Count > Result[j].Count
+		}
+		if Result[i].Expected != Result[j].Expected {
+			return Result[i].Expected < Result[j].Expected
+		}
+		return Result[i].Typed < Result[j].Typed
+	})
+
+	if len(Result) > Count {
+		Result = Result[:Count]
+	}
+	return Result
+}
+
+// ToMap converts Matrix to map keyed by expected and typed keys, e.g. "a>s", to be saved
+func (m *Matrix) ToMap() map[string]int {
+	Result := map[string]int{}
+	for k, v := range m.counts {
+		Result[fmt.Sprintf("%c>%c", k.Expected, k.Typed)] = v
+	}
+	return Result
+}
+
+// FromMap makes Matrix from map made by ToMap. Broken keys are ignored.
+func FromMap(Map map[string]int) *Matrix {
+	Result := NewMatrix()
+	for k, v := range Map {
+		if len(k) != 3 || k[1] != '>' {
+			continue
+		}
+		Result.counts[Pair{k[0], k[2]}] += v
+		Result.total += v
+	}
+	return Result
+}
@@ -0,0 +1,89 @@
+package mods
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mod is set of gameplay modifiers
+type Mod uint8
+
+const (
+	// HiddenRoma hides roman line of the sentence
+	HiddenRoma Mod = 1 << iota
+	// HiddenHiragana hides hiragana line of the sentence, so only lyrics are readable
+	HiddenHiragana
+	// NoKeyboard hides on-screen keyboard
+	NoKeyboard
+	// NoHighlight stops highlighting next key on the keyboard
+	NoHighlight
+	// PerfectOnly fails the sentence on the first miss
+	PerfectOnly
+
+	// None means playing without modifiers
+	None Mod = 0
+)
+
+type modInfo struct {
+	mod       Mod
+	name      string
+	shortName string
+}
+
+var (
+	modInfos = []modInfo{
+		{HiddenRoma, "hidden-roma", "HR"},
+		{HiddenHiragana, "hidden-hiragana", "HH"},
+		{NoKeyboard, "no-keyboard", "NK"},
+		{NoHighlight, "no-highlight", "NH"},
+		{PerfectOnly, "perfect-only", "PF"},
+	}
+)
+
+// Has returns whether the set has all of the modifiers
+func (m Mod) Has(Other Mod) bool {
+	return m&Other == Other
+}
+
+// String returns short names of modifiers joined, e.g. "HR+PF"
+func (m Mod) String() string {
+	Names := make([]string, 0, len(modInfos))
+	for _, v := range modInfos {
+		if m.Has(v.mod) {
+			Names = append(Names, v.shortName)
+		}
+	}
+	return strings.Join(Names, "+")
+}
+
+// Parse makes set of modifiers from names separated by comma, e.g. "hidden-roma,perfect-only"
+func Parse(Text string) (Mod, error) {
+	Result := None
+	for _, Name := range strings.Split(Text, ",") {
+		Name = strings.TrimSpace(Name)
+		if Name == "" {
+			continue
+		}
+
+		Found := false
+		for _, v := range modInfos {
+			if v.name == Name {
+				Result |= v.mod
+				Found = true
+			}
+		}
+		if !Found {
+			return None, fmt.Errorf("unknown mod %q", Name)
+		}
+	}
+	return Result, nil
+}
+
+// Names returns names of all modifiers which Parse accepts
+func Names() []string {
+	Result := make([]string, 0, len(modInfos))
+	for _, v := range modInfos {
+		Result = append(Result, v.name)
+	}
+	return Result
+}
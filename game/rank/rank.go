@@ -1,28 +1,28 @@
 package rank
 
+import (
+	"musicaltyper-go/game/draw/color"
+)
+
 // Rank expresses player rank
 type Rank struct {
 	bottom float64
 	text   string
+	color  color.Color
+	table  *Table
 }
 
-// FromAchievementRate decides player rank
-func FromAchievementRate(rate float64) Rank {
-	for _, rank := range ranks {
-		if rank.bottom < rate*100 {
-			return rank
-		}
-	}
-	return ranks[len(ranks)-1]
-}
-
+// GetNextRank returns one rank higher in the same table
 func (r Rank) GetNextRank() (*Rank, bool) {
-	for i, v := range ranks {
+	if r.table == nil {
+		return nil, false
+	}
+	for i, v := range r.table.ranks {
 		if r.bottom == v.bottom {
 			if i == 0 {
 				return nil, false
 			}
-			return &ranks[i-1], true
+			return &r.table.ranks[i-1], true
 		}
 	}
 	return nil, false
@@ -36,22 +36,15 @@ func (r Rank) BorderRate() float64 {
 	return r.bottom
 }
 
-var (
-	ranks = [...]Rank{
-		{200, "Wow"},
-		{150, "Unexpected"},
-		{125, "Very God"},
-		{100, "God"},
-		{99.5, "Pro"},
-		{99, "Genius"},
-		{98, "Geki-tsuyo"},
-		{97, "tsuyotsuyo"},
-		{94, "AAA"},
-		{90, "AA"},
-		{80, "A"},
-		{60, "B"},
-		{40, "C"},
-		{20, "D"},
-		{10, "E"},
-		{0, "F"}}
-)
+// Color returns color to present the rank
+func (r Rank) Color() color.Color {
+	return r.color
+}
+
+// TableID returns ID of the table which the rank belongs to
+func (r Rank) TableID() string {
+	if r.table == nil {
+		return ""
+	}
+	return r.table.id
+}
@@ -0,0 +1,121 @@
+package rank
+
+import (
+	"fmt"
+	"musicaltyper-go/game/config"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/color"
+	"musicaltyper-go/game/logger"
+	"sort"
+)
+
+// Table is set of ranks sorted from the highest
+type Table struct {
+	id    string
+	ranks []Rank
+}
+
+// NewTable makes Table from configured entries in any order
+func NewTable(ID string, Entries []config.RankEntry) *Table {
+	Ranks := make([]Rank, 0, len(Entries))
+	for _, v := range Entries {
+		Ranks = append(Ranks, Rank{
+			bottom: v.Border,
+			text:   v.Label,
+			color:  color.FromRGB(v.Color[0], v.Color[1], v.Color[2]),
+		})
+	}
+	return newTable(ID, Ranks)
+}
+
+func newTable(ID string, Ranks []Rank) *Table {
+	Result := &Table{
+		id:    ID,
+		ranks: Ranks,
+	}
+	for i := range Result.ranks {
+		Result.ranks[i].table = Result
+	}
+	sort.SliceStable(Result.ranks, func(i, j int) bool {
+		return Result.ranks[i].bottom > Result.ranks[j].bottom
+	})
+	return Result
+}
+
+// ID returns ID of the table
+func (t *Table) ID() string {
+	return t.id
+}
+
+// FromAchievementRate decides player rank
+func (t *Table) FromAchievementRate(rate float64) Rank {
+	for _, rank := range t.ranks {
+		if rank.bottom < rate*100 {
+			return rank
+		}
+	}
+	return t.ranks[len(t.ranks)-1]
+}
+
+// ForRule returns rank table for scoring rule by configuration
+func ForRule(RuleID string) *Table {
+	Config := config.Get()
+	ID, Exists := Config.RuleRankTables[RuleID]
+	if !Exists {
+		ID = ClassicTableID
+	}
+	return Get(ID)
+}
+
+// Get returns rank table with ID from configuration or built-in ones, or classic table if not found
+func Get(ID string) *Table {
+	if Entries, Exists := config.Get().RankTables[ID]; Exists && len(Entries) > 0 {
+		return NewTable(ID, Entries)
+	}
+	if Table, Exists := builtinTables[ID]; Exists {
+		return Table
+	}
+
+	Logger := logger.NewLogger("Rank")
+	Logger.Warn(fmt.Sprintf("Unknown rank table \"%s\". Using \"%s\" instead.", ID, ClassicTableID))
+	return builtinTables[ClassicTableID]
+}
+
+const (
+	// ClassicTableID is ID of the original rank table, from "Wow" down to "F"
+	ClassicTableID = "classic"
+	// SimpleTableID is ID of S/A/B/C table for newcomers
+	SimpleTableID = "simple"
+)
+
+var (
+	builtinTables = map[string]*Table{
+		ClassicTableID: newTable(ClassicTableID, []Rank{
+			{200, "Wow", classicColor(200), nil},
+			{150, "Unexpected", classicColor(150), nil},
+			{125, "Very God", classicColor(125), nil},
+			{100, "God", classicColor(100), nil},
+			{99.5, "Pro", classicColor(99.5), nil},
+			{99, "Genius", classicColor(99), nil},
+			{98, "Geki-tsuyo", classicColor(98), nil},
+			{97, "tsuyotsuyo", classicColor(97), nil},
+			{94, "AAA", classicColor(94), nil},
+			{90, "AA", classicColor(90), nil},
+			{80, "A", classicColor(80), nil},
+			{60, "B", classicColor(60), nil},
+			{40, "C", classicColor(40), nil},
+			{20, "D", classicColor(20), nil},
+			{10, "E", classicColor(10), nil},
+			{0, "F", classicColor(0), nil}}),
+		SimpleTableID: newTable(SimpleTableID, []Rank{
+			{90, "S", constants.RedColor, nil},
+			{75, "A", constants.BlueThickColor, nil},
+			{50, "B", constants.GreenThickColor, nil},
+			{0, "C", constants.ComboTextColor, nil}}),
+	}
+)
+
+// classicColor gets darker as border rises
+func classicColor(Border float64) color.Color {
+	return constants.RedColor.Darker(int(150.0 * (Border / 200)))
+}
@@ -0,0 +1,258 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/mods"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+Replay file layout:
+	"MTRP" magic, format version (1 byte), and gzip stream of
+	header strings (uvarint length + bytes): MapTitle, MapHash, RomaTableID
+	RecordedAt (varint, unix seconds)
+	Rate (uvarint, permille)
+	Mods (1 byte)
+	ChartID, MapVersion (uvarint length + bytes)
+	event count (uvarint)
+	events: time delta from previous event (varint, ms), key (1 byte), judge (1 byte)
+*/
+
+const (
+	magic = "MTRP"
+	// FormatVersion is version of replay file layout written by Save
+	FormatVersion = 1
+	// Extension is file extension of replay file
+	Extension = ".mtr"
+)
+
+const (
+	// maxPreallocatedEvents limits memory reserved from event count in the file, which may be broken
+	maxPreallocatedEvents = 1 << 16
+	// maxStringLength is the longest header string accepted
+	maxStringLength = 1 << 12
+)
+
+// ErrNotReplay is returned when the file is not replay file
+var ErrNotReplay = errors.New("not a replay file")
+
+// Write encodes Replay to writer
+func (r *Replay) Write(w io.Writer) error {
+	if _, Err := io.WriteString(w, magic); Err != nil {
+		return Err
+	}
+	if _, Err := w.Write([]byte{FormatVersion}); Err != nil {
+		return Err
+	}
+
+	Compressed := gzip.NewWriter(w)
+	Buffered := bufio.NewWriter(Compressed)
+
+	for _, v := range []string{r.MapTitle, r.MapHash, r.RomaTableID} {
+		writeString(Buffered, v)
+	}
+	writeVarint(Buffered, r.RecordedAt.Unix())
+	writeUvarint(Buffered, uint64(math.Round(r.Rate*1000)))
+	Buffered.WriteByte(byte(r.Mods))
+	for _, v := range []string{r.ChartID, r.MapVersion} {
+		writeString(Buffered, v)
+	}
+	writeUvarint(Buffered, uint64(len(r.Events)))
+
+	var PrevTime int64
+	for _, v := range r.Events {
+		Time := int64(math.Round(v.Time * 1000))
+		writeVarint(Buffered, Time-PrevTime)
+		Buffered.WriteByte(v.Key)
+		Buffered.WriteByte(byte(v.Judge))
+		PrevTime = Time
+	}
+
+	if Err := Buffered.Flush(); Err != nil {
+		return Err
+	}
+	return Compressed.Close()
+}
+
+// Read decodes Replay from reader
+func Read(r io.Reader) (*Replay, error) {
+	Header := make([]byte, len(magic)+1)
+	if _, Err := io.ReadFull(r, Header); Err != nil {
+		return nil, Err
+	}
+	if string(Header[:len(magic)]) != magic {
+		return nil, ErrNotReplay
+	}
+	Version := Header[len(magic)]
+	if Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported replay format version %d", Version)
+	}
+
+	Compressed, Err := gzip.NewReader(r)
+	if Err != nil {
+		return nil, Err
+	}
+	defer Compressed.Close()
+	Buffered := bufio.NewReader(Compressed)
+
+	Result := new(Replay)
+	Strings, Err := readStrings(Buffered, 3)
+	if Err != nil {
+		return nil, Err
+	}
+	Result.MapTitle, Result.MapHash, Result.RomaTableID = Strings[0], Strings[1], Strings[2]
+
+	RecordedAt, Err := binary.ReadVarint(Buffered)
+	if Err != nil {
+		return nil, Err
+	}
+	Result.RecordedAt = time.Unix(RecordedAt, 0)
+
+	Rate, Err := binary.ReadUvarint(Buffered)
+	if Err != nil {
+		return nil, Err
+	}
+	Result.Rate = float64(Rate) / 1000
+
+	Mods, Err := Buffered.ReadByte()
+	if Err != nil {
+		return nil, Err
+	}
+	Result.Mods = mods.Mod(Mods)
+
+	Strings, Err = readStrings(Buffered, 2)
+	if Err != nil {
+		return nil, Err
+	}
+	Result.ChartID, Result.MapVersion = Strings[0], Strings[1]
+
+	Count, Err := binary.ReadUvarint(Buffered)
+	if Err != nil {
+		return nil, Err
+	}
+
+	Capacity := Count
+	if Capacity > maxPreallocatedEvents {
+		Capacity = maxPreallocatedEvents
+	}
+	Result.Events = make([]Event, 0, Capacity)
+	var Time int64
+	for i := uint64(0); i < Count; i++ {
+		Delta, Err := binary.ReadVarint(Buffered)
+		if Err != nil {
+			return nil, Err
+		}
+		Body := make([]byte, 2)
+		if _, Err := io.ReadFull(Buffered, Body); Err != nil {
+			return nil, Err
+		}
+		Time += Delta
+		Result.Events = append(Result.Events, Event{
+			Time:  float64(Time) / 1000,
+			Key:   Body[0],
+			Judge: judge.Judge(Body[1]),
+		})
+	}
+	return Result, nil
+}
+
+// Save writes Replay to new file in directory, and returns its path
+func (r *Replay) Save(Directory string) (string, error) {
+	if Err := os.MkdirAll(Directory, 0755); Err != nil {
+		return "", Err
+	}
+
+	Hash := r.MapHash
+	if len(Hash) > 8 {
+		Hash = Hash[:8]
+	}
+	Name := r.RecordedAt.Format("20060102-150405") + "_" + Hash
+
+	//Plays of the same chart can be saved within a second, e.g. retry right after start. Never overwrite them.
+	var (
+		Path string
+		File *os.File
+		Err  error
+	)
+	for i := 1; ; i++ {
+		Path = filepath.Join(Directory, Name+Extension)
+		if i > 1 {
+			Path = filepath.Join(Directory, fmt.Sprintf("%s_%d%s", Name, i, Extension))
+		}
+		File, Err = os.OpenFile(Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !os.IsExist(Err) {
+			break
+		}
+	}
+	if Err != nil {
+		return "", Err
+	}
+	defer File.Close()
+
+	if Err := r.Write(File); Err != nil {
+		return "", Err
+	}
+	return Path, nil
+}
+
+// Load reads Replay from file
+func Load(Path string) (*Replay, error) {
+	File, Err := os.Open(Path)
+	if Err != nil {
+		return nil, wrapError(Path, Err)
+	}
+	defer File.Close()
+
+	Result, Err := Read(bufio.NewReader(File))
+	if Err != nil {
+		return nil, wrapError(Path, Err)
+	}
+	return Result, nil
+}
+
+func wrapError(Path string, Err error) error {
+	return fmt.Errorf("%s: %w", Path, Err)
+}
+
+func readStrings(r *bufio.Reader, Count int) ([]string, error) {
+	Result := make([]string, Count)
+	for i := range Result {
+		Len, Err := binary.ReadUvarint(r)
+		if Err != nil {
+			return nil, Err
+		}
+		if Len > maxStringLength {
+			return nil, fmt.Errorf("header string is too long: %d bytes", Len)
+		}
+		Data := make([]byte, Len)
+		if _, Err := io.ReadFull(r, Data); Err != nil {
+			return nil, Err
+		}
+		Result[i] = string(Data)
+	}
+	return Result, nil
+}
+
+func writeString(w *bufio.Writer, v string) {
+	writeUvarint(w, uint64(len(v)))
+	w.WriteString(v)
+}
+
+func writeUvarint(w *bufio.Writer, v uint64) {
+	Buf := make([]byte, binary.MaxVarintLen64)
+	w.Write(Buf[:binary.PutUvarint(Buf, v)])
+}
+
+func writeVarint(w *bufio.Writer, v int64) {
+	Buf := make([]byte, binary.MaxVarintLen64)
+	w.Write(Buf[:binary.PutVarint(Buf, v)])
+}
@@ -0,0 +1,123 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/mods"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// encode makes replay file of the version, whose body is written by writeBody
+func encode(t *testing.T, Version byte, writeBody func(*bufio.Writer)) []byte {
+	var Data bytes.Buffer
+	Data.WriteString(magic)
+	Data.WriteByte(Version)
+
+	Compressed := gzip.NewWriter(&Data)
+	Buffered := bufio.NewWriter(Compressed)
+	writeBody(Buffered)
+	if Err := Buffered.Flush(); Err != nil {
+		t.Fatal(Err)
+	}
+	if Err := Compressed.Close(); Err != nil {
+		t.Fatal(Err)
+	}
+	return Data.Bytes()
+}
+
+var testEvents = []Event{
+	{Time: 1.5, Key: 'a', Judge: judge.CORRECT},
+	{Time: 1.75, Key: 'x', Judge: judge.MISS},
+	{Time: 3, Key: 'i', Judge: judge.CORRECT},
+}
+
+func TestRoundTrip(t *testing.T) {
+	Original := &Replay{
+		MapTitle:    "キミのチカラ",
+		MapHash:     "0123456789abcdef",
+		ChartID:     "chart",
+		MapVersion:  "v2",
+		RomaTableID: "table",
+		RecordedAt:  time.Unix(1600000000, 0),
+		Rate:        1.25,
+		Mods:        mods.HiddenRoma | mods.PerfectOnly,
+		Events:      testEvents,
+	}
+
+	var Data bytes.Buffer
+	if Err := Original.Write(&Data); Err != nil {
+		t.Fatal(Err)
+	}
+	if Data.Bytes()[len(magic)] != FormatVersion {
+		t.Fatalf("written version = %d, want %d", Data.Bytes()[len(magic)], FormatVersion)
+	}
+
+	Loaded, Err := Read(&Data)
+	if Err != nil {
+		t.Fatal(Err)
+	}
+	if !reflect.DeepEqual(Loaded, Original) {
+		t.Errorf("loaded %+v, want %+v", Loaded, Original)
+	}
+}
+
+func TestReadRejectsBrokenFile(t *testing.T) {
+	Cases := map[string][]byte{
+		"magic":   []byte("MTRX\x01"),
+		"version": []byte(magic + "\x09"),
+		"empty":   encode(t, FormatVersion, func(*bufio.Writer) {}),
+		"string": encode(t, FormatVersion, func(w *bufio.Writer) {
+			writeUvarint(w, maxStringLength+1)
+		}),
+		"events": encode(t, FormatVersion, func(w *bufio.Writer) {
+			for _, v := range []string{"title", "hash", "table"} {
+				writeString(w, v)
+			}
+			writeVarint(w, 0)
+			writeUvarint(w, 1000)
+			w.WriteByte(0)
+			for _, v := range []string{"chart", "v1"} {
+				writeString(w, v)
+			}
+			//Huge count without events must fail without reserving memory for it.
+			writeUvarint(w, 1<<62)
+		}),
+	}
+
+	for Name, Data := range Cases {
+		if _, Err := Read(bytes.NewReader(Data)); Err == nil {
+			t.Errorf("%s: broken file was read without error", Name)
+		}
+	}
+}
+
+func TestSaveKeepsFileOfSameSecond(t *testing.T) {
+	Directory, Err := ioutil.TempDir("", "replay")
+	if Err != nil {
+		t.Fatal(Err)
+	}
+	defer os.RemoveAll(Directory)
+
+	Replay := &Replay{MapHash: "0123456789abcdef", RecordedAt: time.Unix(1600000000, 0), Rate: 1, Events: testEvents}
+	Paths := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		Path, Err := Replay.Save(Directory)
+		if Err != nil {
+			t.Fatal(Err)
+		}
+		if Paths[Path] {
+			t.Fatalf("saved into %s again", Path)
+		}
+		Paths[Path] = true
+
+		if _, Err := Load(Path); Err != nil {
+			t.Error(Err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/mods"
+	"time"
+)
+
+// Replay has every key input of a play with the identity of played beatmap
+type Replay struct {
+	MapTitle string
+	// MapHash is fingerprint of the beatmap
+	MapHash string
+	// ChartID and MapVersion are identity of the chart across its versions
+	ChartID     string
+	MapVersion  string
+	RomaTableID string
+	RecordedAt  time.Time
+	// Rate is playback rate of the song. Times of events are on song time regardless of it.
+	Rate float64
+	Mods mods.Mod
+
+	Events []Event
+}
+
+// Event is single key input on song time
+type Event struct {
+	Time  float64
+	Key   byte
+	Judge judge.Judge
+}
+
+// NewReplay makes empty Replay for Beatmap
+func NewReplay(Map *beatmap.Beatmap) *Replay {
+	return &Replay{
+		MapTitle:    Map.Properties["title"],
+		MapHash:     Map.Fingerprint,
+		ChartID:     Map.ChartID,
+		MapVersion:  Map.Version(),
+		RomaTableID: beatmap.RomaTableID,
+		RecordedAt:  time.Now(),
+		Rate:        1,
+		Events:      make([]Event, 0),
+	}
+}
+
+// Record appends key input
+func (r *Replay) Record(Time float64, Key byte, Judge judge.Judge) {
+	r.Events = append(r.Events, Event{
+		Time:  Time,
+		Key:   Key,
+		Judge: Judge,
+	})
+}
+
+// IsFor returns whether Replay was recorded on the beatmap
+func (r *Replay) IsFor(Map *beatmap.Beatmap) bool {
+	return r.MapHash == Map.Fingerprint
+}
+
+// IsForOlderVersion returns whether Replay was recorded on another version of the chart
+func (r *Replay) IsForOlderVersion(Map *beatmap.Beatmap) bool {
+	return !r.IsFor(Map) && r.ChartID == Map.ChartID
+}
@@ -0,0 +1,48 @@
+package scoring
+
+import (
+	"musicaltyper-go/game/beatmap"
+	Constants "musicaltyper-go/game/constants"
+)
+
+const (
+	// AccuracyID is ID of the bounded accuracy-weighted scoring
+	AccuracyID = "accuracy"
+)
+
+// accuracyRule rates how much of the song was typed, weighted by accuracy. Its achievement rate is always 0~100%.
+type accuracyRule struct{}
+
+func init() {
+	register(accuracyRule{})
+}
+
+func (accuracyRule) ID() string {
+	return AccuracyID
+}
+
+func (accuracyRule) AddPoint(s *Stats, Sentence *beatmap.Sentence, isTypeOK, isThisSentenceEnded bool, TypeSpeed float64) int {
+	if !isTypeOK {
+		return 0
+	}
+	s.Point += Constants.OneCharPoint
+	s.PerfectPoint += Constants.OneCharPoint
+	return Constants.OneCharPoint
+}
+
+func (accuracyRule) AddTLEPoint(s *Stats, Sentence *beatmap.Sentence, RemainingLength int) {
+	s.PerfectPoint += Constants.OneCharPoint * RemainingLength
+}
+
+// ChargesTimeout is true so that untyped roman lowers completion
+func (accuracyRule) ChargesTimeout() bool {
+	return true
+}
+
+func (accuracyRule) GetAchievementRate(s *Stats, Limit bool) float64 {
+	if s.PerfectPoint == 0 {
+		return 0
+	}
+	Completion := float64(s.Point) / float64(s.PerfectPoint)
+	return Completion * s.GetAccuracy()
+}
@@ -0,0 +1,68 @@
+package scoring
+
+import (
+	"math"
+	"musicaltyper-go/game/beatmap"
+	Constants "musicaltyper-go/game/constants"
+)
+
+const (
+	// ClassicID is ID of the original MusicalTyper scoring
+	ClassicID = "classic"
+)
+
+// classicRule rewards speed and combo. Its achievement rate can exceed 100%.
+type classicRule struct{}
+
+func init() {
+	register(classicRule{})
+}
+
+func (classicRule) ID() string {
+	return ClassicID
+}
+
+func (classicRule) AddPoint(s *Stats, Sentence *beatmap.Sentence, isTypeOK, isThisSentenceEnded bool, TypeSpeed float64) (point int) {
+	if !isTypeOK {
+		point = Constants.MissPoint
+		s.Point += point
+		return
+	}
+
+	point = int(Constants.OneCharPoint * 10 * TypeSpeed * float64(s.Combo/10))
+	s.Point += point
+	s.PerfectPoint += Constants.OneCharPoint * 10 * Constants.IdealTypeSpeed * s.Combo / 10
+
+	if isThisSentenceEnded {
+		s.PerfectPoint += Constants.ClearPoint + Constants.PerfectPoint
+		s.Point += Constants.ClearPoint
+		if Sentence.MissCount == 0 {
+			s.Point += Constants.PerfectPoint
+		}
+	}
+	return
+}
+
+func (classicRule) AddTLEPoint(s *Stats, Sentence *beatmap.Sentence, RemainingLength int) {
+	s.Point += Constants.CouldntTypeCount * RemainingLength
+	s.PerfectPoint += Constants.OneCharPoint*RemainingLength*40 + Constants.ClearPoint + Constants.PerfectPoint
+}
+
+// ChargesTimeout is false because the original game never charged sentences which time ran out on
+func (classicRule) ChargesTimeout() bool {
+	return false
+}
+
+func (classicRule) GetAchievementRate(s *Stats, Limit bool) float64 {
+	Acc := s.GetAccuracy()
+	PerfectScore := s.PerfectPoint + s.TotalCorrectCount*45
+	Score := float64(s.Point) * Acc
+	if Score <= 0 {
+		return 0
+	}
+
+	if Limit {
+		Score = math.Min(Score, float64(PerfectScore))
+	}
+	return Score / float64(PerfectScore)
+}
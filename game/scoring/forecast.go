@@ -0,0 +1,103 @@
+package scoring
+
+import "musicaltyper-go/game/beatmap"
+
+const (
+	// forecastMinAccuracy and forecastMaxSpeed are bounds of searching needed accuracy and typing speed
+	forecastMinAccuracy = 0.5
+	forecastMaxSpeed    = 20.0
+	// forecastIterations is count of bisection steps, which is precise enough to show
+	forecastIterations = 16
+)
+
+// Projection is what is needed over remaining notes to reach the target achievement rate
+type Projection struct {
+	Target float64
+
+	// Reachable is whether the target can be reached by typing every remaining key correctly and fast enough
+	Reachable bool
+	// Accuracy is accuracy needed at current typing speed, or 0 if it isn't reachable at the speed
+	Accuracy float64
+	// Speed is typing speed needed at current accuracy, or 0 if speed doesn't matter or it isn't reachable at the accuracy
+	Speed float64
+}
+
+// Forecast simulates remaining sentences typed at the accuracy and speed, and returns achievement rate at the end.
+// Remaining are lengths of romaji to type in each remaining sentence.
+func Forecast(Rule ScoringRule, Current Stats, Remaining []int, Accuracy, TypeSpeed float64) float64 {
+	s := Current
+	Misses := 0.0
+	for _, Length := range Remaining {
+		if Length == 0 {
+			continue
+		}
+
+		//Misses per sentence are distributed so that the total meets the accuracy.
+		Misses += float64(Length) * (1 - Accuracy) / Accuracy
+		Sentence := &beatmap.Sentence{MissCount: int(Misses)}
+		Misses -= float64(Sentence.MissCount)
+
+		for i := 0; i < Sentence.MissCount; i++ {
+			s.TotalMissCount++
+			Rule.AddPoint(&s, Sentence, false, false, TypeSpeed)
+			s.Combo = 0
+		}
+		for i := 0; i < Length; i++ {
+			s.TotalCorrectCount++
+			s.Combo++
+			Rule.AddPoint(&s, Sentence, true, i == Length-1, TypeSpeed)
+		}
+	}
+	return Rule.GetAchievementRate(&s, false)
+}
+
+// Project searches accuracy and typing speed needed over remaining sentences to reach the target
+func Project(Rule ScoringRule, Current Stats, Remaining []int, Accuracy, TypeSpeed, Target float64) Projection {
+	Result := Projection{
+		Target:    Target,
+		Reachable: Forecast(Rule, Current, Remaining, 1, forecastMaxSpeed) >= Target,
+	}
+	if !Result.Reachable {
+		return Result
+	}
+
+	if Forecast(Rule, Current, Remaining, 1, TypeSpeed) >= Target {
+		Result.Accuracy = bisect(forecastMinAccuracy, 1, func(Accuracy float64) bool {
+			return Forecast(Rule, Current, Remaining, Accuracy, TypeSpeed) >= Target
+		})
+	}
+
+	Accuracy = clamp(Accuracy, forecastMinAccuracy, 1)
+	if Forecast(Rule, Current, Remaining, Accuracy, 0) < Target && Forecast(Rule, Current, Remaining, Accuracy, forecastMaxSpeed) >= Target {
+		Result.Speed = bisect(0, forecastMaxSpeed, func(Speed float64) bool {
+			return Forecast(Rule, Current, Remaining, Accuracy, Speed) >= Target
+		})
+	}
+	return Result
+}
+
+// bisect returns the lowest value from Low to High which satisfies ok, assuming ok(High) and monotonicity
+func bisect(Low, High float64, ok func(float64) bool) float64 {
+	if ok(Low) {
+		return Low
+	}
+	for i := 0; i < forecastIterations; i++ {
+		Middle := (Low + High) / 2
+		if ok(Middle) {
+			High = Middle
+		} else {
+			Low = Middle
+		}
+	}
+	return High
+}
+
+func clamp(Value, Min, Max float64) float64 {
+	if Value < Min {
+		return Min
+	}
+	if Value > Max {
+		return Max
+	}
+	return Value
+}
@@ -0,0 +1,110 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+)
+
+// forecastTolerance is how far Accuracy and Speed found by bisection may be from expected
+const forecastTolerance = 0.001
+
+func TestProject(t *testing.T) {
+	var (
+		Remaining = []int{10, 10, 10, 10}
+		// Typed well so far, which meets low targets without typing fast or accurately
+		Good = Stats{Combo: 100, Point: 5000, PerfectPoint: 6000, TotalCorrectCount: 100}
+	)
+
+	Cases := []struct {
+		name      string
+		rule      ScoringRule
+		current   Stats
+		remaining []int
+		accuracy  float64
+		speed     float64
+		target    float64
+		want      Projection
+	}{
+		{"classic/reachable", classicRule{}, Stats{}, Remaining, 1, 3, 0.5,
+			Projection{Target: 0.5, Reachable: true, Accuracy: 0.9677, Speed: 2.0203}},
+		{"classic/too slow for any accuracy", classicRule{}, Stats{}, Remaining, 1, 1, 0.5,
+			Projection{Target: 0.5, Reachable: true, Accuracy: 0, Speed: 2.0203}},
+		{"classic/unreachable", classicRule{}, Stats{Point: -2000, PerfectPoint: 100000, TotalCorrectCount: 10}, []int{5}, 1, 3, 1,
+			Projection{Target: 1, Reachable: false}},
+		{"classic/accuracy already met", classicRule{}, Good, Remaining, 1, 3, 0.2,
+			Projection{Target: 0.2, Reachable: true, Accuracy: forecastMinAccuracy, Speed: 0.5585}},
+		{"classic/speed already met", classicRule{}, Good, Remaining, 1, 3, 0.03,
+			Projection{Target: 0.03, Reachable: true, Accuracy: forecastMinAccuracy, Speed: 0}},
+
+		{"accuracy/reachable", accuracyRule{}, Stats{}, Remaining, 0.95, 3, 0.9,
+			Projection{Target: 0.9, Reachable: true, Accuracy: 0.8889, Speed: 0}},
+		{"accuracy/unreachable", accuracyRule{}, Stats{Point: 100, PerfectPoint: 1000, TotalCorrectCount: 10}, []int{10}, 1, 3, 0.5,
+			Projection{Target: 0.5, Reachable: false}},
+		{"accuracy/accuracy already met", accuracyRule{}, Stats{Point: 1000, PerfectPoint: 1000, TotalCorrectCount: 100}, []int{5}, 1, 3, 0.5,
+			Projection{Target: 0.5, Reachable: true, Accuracy: forecastMinAccuracy, Speed: 0}},
+		//Speed never matters in accuracy rule, so needed speed is 0 even for high target.
+		{"accuracy/speed already met", accuracyRule{}, Stats{}, Remaining, 1, 0, 0.99,
+			Projection{Target: 0.99, Reachable: true, Accuracy: 0.9756, Speed: 0}},
+	}
+
+	for _, c := range Cases {
+		Got := Project(c.rule, c.current, c.remaining, c.accuracy, c.speed, c.target)
+		if Got.Target != c.want.Target || Got.Reachable != c.want.Reachable ||
+			math.Abs(Got.Accuracy-c.want.Accuracy) > forecastTolerance || math.Abs(Got.Speed-c.want.Speed) > forecastTolerance {
+			t.Errorf("%s: got %+v, want %+v", c.name, Got, c.want)
+		}
+
+		//Found values must be enough to reach the target.
+		if Got.Accuracy > 0 {
+			if Rate := Forecast(c.rule, c.current, c.remaining, Got.Accuracy, c.speed); Rate < c.target {
+				t.Errorf("%s: accuracy %v reaches only %v", c.name, Got.Accuracy, Rate)
+			}
+		}
+		if Got.Speed > 0 {
+			if Rate := Forecast(c.rule, c.current, c.remaining, c.accuracy, Got.Speed); Rate < c.target {
+				t.Errorf("%s: speed %v reaches only %v", c.name, Got.Speed, Rate)
+			}
+		}
+	}
+}
+
+func TestForecastKeepsCurrent(t *testing.T) {
+	Current := Stats{Combo: 3, Point: 120, PerfectPoint: 300, TotalCorrectCount: 12, TotalMissCount: 2}
+	Before := Current
+	for _, Rule := range []ScoringRule{classicRule{}, accuracyRule{}} {
+		Forecast(Rule, Current, []int{10, 0, 5}, 0.8, 3)
+		if Current != Before {
+			t.Errorf("%s: forecast changed current stats to %+v", Rule.ID(), Current)
+		}
+		if Got, Want := Forecast(Rule, Current, nil, 0.8, 3), Rule.GetAchievementRate(&Current, false); Got != Want {
+			t.Errorf("%s: forecast without remaining notes = %v, want current %v", Rule.ID(), Got, Want)
+		}
+	}
+}
+
+func TestBisect(t *testing.T) {
+	Cases := []struct {
+		name      string
+		low, high float64
+		threshold float64
+		want      float64
+	}{
+		{"inside", 0, 20, 7.3, 7.3},
+		{"low already met", 0.5, 1, 0.2, 0.5},
+		{"only high", 0.5, 1, 1, 1},
+	}
+
+	for _, c := range Cases {
+		Calls := 0
+		Got := bisect(c.low, c.high, func(Value float64) bool {
+			Calls++
+			return Value >= c.threshold
+		})
+		if Got < c.threshold || Got-c.want > (c.high-c.low)/(1<<forecastIterations) {
+			t.Errorf("%s: got %v, want %v", c.name, Got, c.want)
+		}
+		if Calls > forecastIterations+1 {
+			t.Errorf("%s: ok was called %d times", c.name, Calls)
+		}
+	}
+}
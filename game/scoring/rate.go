@@ -0,0 +1,15 @@
+package scoring
+
+const (
+	// fastRateBonus is extra multiplier per rate above normal speed
+	fastRateBonus = 0.8
+)
+
+// RateMultiplier returns multiplier of point by playback rate.
+// Slower play loses point in proportion, and faster play earns a bit less than in proportion.
+func RateMultiplier(Rate float64) float64 {
+	if Rate <= 1 {
+		return Rate
+	}
+	return 1 + (Rate-1)*fastRateBonus
+}
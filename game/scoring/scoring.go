@@ -0,0 +1,76 @@
+package scoring
+
+import (
+	"fmt"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/config"
+	"musicaltyper-go/game/logger"
+)
+
+// Stats has counters of a play which scoring rules read and write
+type Stats struct {
+	Combo        int
+	Point        int
+	PerfectPoint int
+
+	TotalCorrectCount int
+	TotalMissCount    int
+}
+
+// GetAccuracy calculates accuracy
+func (s *Stats) GetAccuracy() float64 {
+	if s.TotalCorrectCount == 0 {
+		return 0
+	}
+
+	return float64(s.TotalCorrectCount) / float64(s.TotalMissCount+s.TotalCorrectCount)
+}
+
+// ScoringRule decides points and achievement rate.
+// Counters other than Point and PerfectPoint are updated by caller before calling it.
+type ScoringRule interface {
+	// ID identifies the rule. Scores with different IDs must not be compared.
+	ID() string
+
+	// AddPoint adds point on a key typed, and returns added point
+	AddPoint(s *Stats, Sentence *beatmap.Sentence, isTypeOK, isThisSentenceEnded bool, TypeSpeed float64) int
+
+	// AddTLEPoint adds point when failed to type remaining roman of the sentence
+	AddTLEPoint(s *Stats, Sentence *beatmap.Sentence, RemainingLength int)
+	// ChargesTimeout returns whether remaining roman is charged by AddTLEPoint when time of the sentence runs out
+	ChargesTimeout() bool
+
+	// GetAchievementRate calculates achievement rate. Limit caps it to 100%.
+	GetAchievementRate(s *Stats, Limit bool) float64
+}
+
+const (
+	// PropertyName is beatmap property to specify scoring rule
+	PropertyName = "scoring_rule"
+)
+
+var (
+	rules = map[string]ScoringRule{}
+)
+
+func register(Rule ScoringRule) {
+	rules[Rule.ID()] = Rule
+}
+
+// Get returns scoring rule with ID, or classic rule if not found
+func Get(ID string) ScoringRule {
+	if Rule, Exists := rules[ID]; Exists {
+		return Rule
+	}
+	Logger := logger.NewLogger("Scoring")
+	Logger.Warn(fmt.Sprintf("Unknown scoring rule \"%s\". Using \"%s\" instead.", ID, ClassicID))
+	return rules[ClassicID]
+}
+
+// ForBeatmap decides scoring rule by beatmap property, or by configuration
+func ForBeatmap(Map *beatmap.Beatmap) ScoringRule {
+	if ID, Exists := Map.Properties[PropertyName]; Exists {
+		return Get(ID)
+	}
+	return Get(config.Get().ScoringRule)
+}
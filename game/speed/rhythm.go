@@ -0,0 +1,86 @@
+package speed
+
+import "math"
+
+// RhythmStats is summary of how keys were typed, apart from overall speed. Times are in seconds.
+type RhythmStats struct {
+	// Reaction is average delay from beginning of sentence to its first key, and LastReaction is the latest one
+	Reaction     float64
+	LastReaction float64
+	// IntervalSpread is standard deviation of intervals between keys in a sentence. Smaller is steadier.
+	IntervalSpread float64
+	// TypingKPS is typing speed excluding reaction, i.e. from first key to last key of each sentence
+	TypingKPS float64
+}
+
+// Scale converts stats measured in song time into real time of the playback rate
+func (s RhythmStats) Scale(Rate float64) RhythmStats {
+	return RhythmStats{
+		Reaction:       s.Reaction / Rate,
+		LastReaction:   s.LastReaction / Rate,
+		IntervalSpread: s.IntervalSpread / Rate,
+		TypingKPS:      s.TypingKPS * Rate,
+	}
+}
+
+// Rhythm records reaction to each sentence and intervals between keys in it
+type Rhythm struct {
+	reactions []float64
+	intervals []float64
+
+	// lastKey is time of the last key in current sentence, or negative before its first key
+	lastKey float64
+}
+
+// NewRhythm makes empty Rhythm
+func NewRhythm() *Rhythm {
+	return &Rhythm{
+		reactions: make([]float64, 0),
+		intervals: make([]float64, 0),
+		lastKey:   -1,
+	}
+}
+
+// StartSentence makes next key the first one of a sentence
+func (r *Rhythm) StartSentence() {
+	r.lastKey = -1
+}
+
+// Record records correct key typed at Now, in the sentence began at SentenceStart
+func (r *Rhythm) Record(SentenceStart, Now float64) {
+	if r.lastKey < 0 {
+		r.reactions = append(r.reactions, math.Max(0, Now-SentenceStart))
+	} else {
+		r.intervals = append(r.intervals, Now-r.lastKey)
+	}
+	r.lastKey = Now
+}
+
+// Stats calculates summary of recorded keys
+func (r *Rhythm) Stats() RhythmStats {
+	Result := RhythmStats{}
+	if len(r.reactions) > 0 {
+		Result.Reaction = mean(r.reactions)
+		Result.LastReaction = r.reactions[len(r.reactions)-1]
+	}
+	if len(r.intervals) > 0 {
+		Mean := mean(r.intervals)
+		Variance := 0.0
+		for _, v := range r.intervals {
+			Variance += (v - Mean) * (v - Mean)
+		}
+		Result.IntervalSpread = math.Sqrt(Variance / float64(len(r.intervals)))
+		if Mean > 0 {
+			Result.TypingKPS = 1 / Mean
+		}
+	}
+	return Result
+}
+
+func mean(Values []float64) float64 {
+	Sum := 0.0
+	for _, v := range Values {
+		Sum += v
+	}
+	return Sum / float64(len(Values))
+}
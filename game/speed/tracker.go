@@ -0,0 +1,128 @@
+package speed
+
+import (
+	"math"
+)
+
+const (
+	// WindowWidth is width of sliding window in seconds
+	WindowWidth = 3.0
+)
+
+// Stats is snapshot of typing speeds in key per second
+type Stats struct {
+	Instant float64
+	Window  float64
+	Average float64
+}
+
+// Tracker calculates typing speed from timestamped key events.
+// Only the time while it is active (e.g. input is enabled and game is not paused) counts.
+type Tracker struct {
+	keys []float64
+
+	active      bool
+	activeSince float64
+	activeTime  float64
+}
+
+// NewTracker makes inactive Tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		keys: make([]float64, 0),
+	}
+}
+
+// SetActive switches whether the time from Now counts for speed
+func (t *Tracker) SetActive(Now float64, Active bool) {
+	if t.active == Active {
+		return
+	}
+	if t.active {
+		t.activeTime = t.toActiveTime(Now)
+	} else {
+		t.activeSince = Now
+	}
+	t.active = Active
+}
+
+// IsActive returns whether Tracker is counting time
+func (t *Tracker) IsActive() bool {
+	return t.active
+}
+
+// Record records key typed at Now. Keys while inactive are ignored.
+func (t *Tracker) Record(Now float64) {
+	if !t.active {
+		return
+	}
+	t.keys = append(t.keys, t.toActiveTime(Now))
+}
+
+// Count returns the number of recorded keys
+func (t *Tracker) Count() int {
+	return len(t.keys)
+}
+
+// toActiveTime converts song time to the total time being active
+func (t *Tracker) toActiveTime(Now float64) float64 {
+	if !t.active {
+		return t.activeTime
+	}
+	return t.activeTime + math.Max(0, Now-t.activeSince)
+}
+
+// Instant calculates speed from the interval of last two keys, decaying while no key comes
+func (t *Tracker) Instant(Now float64) float64 {
+	if len(t.keys) < 2 {
+		return 0
+	}
+	var (
+		Last     = t.keys[len(t.keys)-1]
+		Interval = Last - t.keys[len(t.keys)-2]
+		Idle     = t.toActiveTime(Now) - Last
+	)
+	Interval = math.Max(Interval, Idle)
+	if Interval <= 0 {
+		return 0
+	}
+	return 1 / Interval
+}
+
+// Window calculates speed from keys in last WindowWidth seconds of active time
+func (t *Tracker) Window(Now float64) float64 {
+	Current := t.toActiveTime(Now)
+	Width := math.Min(WindowWidth, Current)
+	if Width <= 0 {
+		return 0
+	}
+
+	Count := 0
+	for i := len(t.keys) - 1; i >= 0 && t.keys[i] > Current-Width; i-- {
+		Count++
+	}
+	return float64(Count) / Width
+}
+
+// Average calculates speed over whole active time
+func (t *Tracker) Average(Now float64) float64 {
+	Current := t.toActiveTime(Now)
+	if Current <= 0 {
+		return 0
+	}
+	return float64(len(t.keys)) / Current
+}
+
+// Stats calculates all kinds of speeds at once
+func (t *Tracker) Stats(Now float64) Stats {
+	return Stats{
+		Instant: t.Instant(Now),
+		Window:  t.Window(Now),
+		Average: t.Average(Now),
+	}
+}
+
+// ToKPM converts key per second to key per minute
+func ToKPM(KPS float64) float64 {
+	return KPS * 60
+}
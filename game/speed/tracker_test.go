@@ -0,0 +1,118 @@
+package speed
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	keyEvent = iota
+	startEvent
+	stopEvent
+)
+
+// logEvent is a key or a switch of being active at the time, to feed Tracker
+type logEvent struct {
+	kind int
+	time float64
+}
+
+// keys makes key events at the times
+func keys(Times ...float64) []logEvent {
+	Result := make([]logEvent, len(Times))
+	for i, v := range Times {
+		Result[i] = logEvent{keyEvent, v}
+	}
+	return Result
+}
+
+// replayLog feeds the events to new Tracker
+func replayLog(Log ...[]logEvent) *Tracker {
+	Result := NewTracker()
+	for _, Events := range Log {
+		for _, v := range Events {
+			switch v.kind {
+			case keyEvent:
+				Result.Record(v.time)
+			case startEvent:
+				Result.SetActive(v.time, true)
+			case stopEvent:
+				Result.SetActive(v.time, false)
+			}
+		}
+	}
+	return Result
+}
+
+// start and stop make events switching being active at the time
+func start(Time float64) []logEvent {
+	return []logEvent{{startEvent, Time}}
+}
+
+func stop(Time float64) []logEvent {
+	return []logEvent{{stopEvent, Time}}
+}
+
+func TestTrackerStats(t *testing.T) {
+	Cases := []struct {
+		name string
+		log  [][]logEvent
+		now  float64
+		want Stats
+	}{
+		{"no keys", [][]logEvent{start(0)}, 2,
+			Stats{}},
+		{"steady", [][]logEvent{start(0), keys(0.5, 1, 1.5, 2)}, 2,
+			Stats{Instant: 2, Window: 2, Average: 2}},
+		{"instant decays while idle", [][]logEvent{start(0), keys(0.5, 1, 1.5, 2)}, 3,
+			Stats{Instant: 1, Window: 4.0 / 3, Average: 4.0 / 3}},
+		{"window drops old keys", [][]logEvent{start(0), keys(0.5, 1, 4, 4.5)}, 5,
+			Stats{Instant: 2, Window: 2.0 / 3, Average: 0.8}},
+		{"keys before start are ignored", [][]logEvent{keys(0.1, 0.2), start(1), keys(1.5, 2)}, 3,
+			Stats{Instant: 1, Window: 1, Average: 1}},
+		//Paused from 1 to 10, so active time is 2 seconds in total.
+		{"paused time is left out", [][]logEvent{start(0), keys(0.5, 1), stop(1), keys(5), start(10), keys(10.5)}, 11,
+			Stats{Instant: 2, Window: 1.5, Average: 1.5}},
+		{"time after stop is left out", [][]logEvent{start(0), keys(0.5, 1), stop(2)}, 100,
+			Stats{Instant: 1, Window: 1, Average: 1}},
+	}
+
+	for _, c := range Cases {
+		Tracker := replayLog(c.log...)
+		Got := Tracker.Stats(c.now)
+		if !nearlyEqual(Got.Instant, c.want.Instant) || !nearlyEqual(Got.Window, c.want.Window) || !nearlyEqual(Got.Average, c.want.Average) {
+			t.Errorf("%s: got %+v, want %+v", c.name, Got, c.want)
+		}
+
+		//Same key log must always give same speeds.
+		if Again := replayLog(c.log...).Stats(c.now); Again != Got {
+			t.Errorf("%s: got %+v for the same log, first %+v", c.name, Again, Got)
+		}
+	}
+}
+
+func TestTrackerSetActive(t *testing.T) {
+	Tracker := replayLog(start(0), keys(1, 2))
+	if !Tracker.IsActive() {
+		t.Fatal("tracker isn't active after started")
+	}
+
+	//Stopping twice must not move the end of active time.
+	Tracker.SetActive(4, false)
+	Tracker.SetActive(8, false)
+	if Tracker.IsActive() {
+		t.Fatal("tracker is active after stopped")
+	}
+	if Got := Tracker.Average(20); !nearlyEqual(Got, 0.5) {
+		t.Errorf("average = %v, want 0.5", Got)
+	}
+
+	Tracker.Record(9)
+	if Tracker.Count() != 2 {
+		t.Errorf("key while stopped was recorded, count = %d", Tracker.Count())
+	}
+}
+
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
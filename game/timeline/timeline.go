@@ -0,0 +1,27 @@
+package timeline
+
+import "musicaltyper-go/game/judge"
+
+// Sample is state of the play right after a judgement
+type Sample struct {
+	Time            float64
+	AchievementRate float64
+	Combo           int
+
+	// Judge is CORRECT, MISS or TLE
+	Judge judge.Judge
+}
+
+// Timeline is samples in order of judgement
+type Timeline []Sample
+
+// MaxCombo returns the longest combo in the timeline
+func (t Timeline) MaxCombo() int {
+	Max := 0
+	for _, v := range t {
+		if v.Combo > Max {
+			Max = v.Combo
+		}
+	}
+	return Max
+}
@@ -0,0 +1,105 @@
+package mainview
+
+import (
+	"math"
+	"musicaltyper-go/game/autoplay"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/rank"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	// checkStep is interval of song time to simulate in CheckChart
+	checkStep = 1.0 / 240
+)
+
+// Autoplay has settings of autoplay, which types instead of the player
+type Autoplay struct {
+	KPS    float64
+	Jitter float64
+
+	// Demo loops the chart instead of showing result, for attract screen. Any key goes back to the previous view.
+	Demo bool
+}
+
+// ChartCheck is result of playing the chart by autoplay
+type ChartCheck struct {
+	KPS             float64
+	AchievementRate float64
+	Rank            rank.Rank
+	Failed          bool
+
+	// TLENotes are indices of notes which couldn't be typed in time
+	TLENotes []int
+}
+
+// Cleared returns whether every sentence was typed in time
+func (c ChartCheck) Cleared() bool {
+	return !c.Failed && len(c.TLENotes) == 0
+}
+
+// startAutoplay lets autoplay type instead of the player
+func (v *gameView) startAutoplay(Autoplay Autoplay) {
+	v.autoplay = autoplay.NewTypist(Autoplay.KPS, Autoplay.Jitter, time.Now().UnixNano())
+	v.demo = Autoplay.Demo
+}
+
+// feedAutoplay passes keys typed by autoplay until now, through the same path as keyboard
+func (v *gameView) feedAutoplay(Renderer *sdl.Renderer) {
+	typeByTypist(v.state, v.autoplay, v.clock.Now(), func(Key sdl.Keycode, Time float64) {
+		v.typeKey(Renderer, Key, Time)
+	})
+}
+
+// typeByTypist passes keys the typist has typed until Now to typeKey, never moving song time backwards
+func typeByTypist(State *GameState, Typist *autoplay.Typist, Now float64, typeKey func(Key sdl.Keycode, Time float64)) {
+	Notes := State.Beatmap.Notes
+	for {
+		//Keys are typed only until the next note begins, so that they never go into the next sentence.
+		Index := State.CurrentSentenceIndex
+		Until := Now
+		if Index+1 < len(Notes) && Notes[Index+1].Time < Until {
+			Until = Notes[Index+1].Time
+		}
+
+		if Key, Time, ok := Typist.Next(Until, Notes[Index].Time, Notes[Index].Sentence, State.IsInputDisabled); ok {
+			typeKey(sdl.Keycode(Key), math.Max(Time, State.CurrentTime))
+			continue
+		}
+
+		State.Update(Until)
+		if State.CurrentSentenceIndex == Index {
+			return
+		}
+	}
+}
+
+// CheckChart plays the chart by autoplay without window, to check whether it can be cleared at the speed
+func CheckChart(Map *beatmap.Beatmap, KPS, Jitter float64) ChartCheck {
+	State := NewGameState(Map.Clone())
+	State.Silent = true
+	Typist := autoplay.NewTypist(KPS, Jitter, 1)
+
+	for Now := 0.0; State.Beatmap.Notes[State.CurrentSentenceIndex].Type != beatmap.END && !State.IsFailed(); Now += checkStep {
+		typeByTypist(State, Typist, Now, func(Key sdl.Keycode, Time float64) {
+			State.Update(Time)
+			State.ParseKeyInput(nil, Key, true)
+		})
+	}
+
+	Result := ChartCheck{
+		KPS:             KPS,
+		AchievementRate: State.GetAchievementRate(false),
+		Rank:            State.GetRank(),
+		Failed:          State.IsFailed(),
+		TLENotes:        make([]int, 0),
+	}
+	for i, Note := range State.Beatmap.Notes[:State.CurrentSentenceIndex] {
+		if Note.Type == beatmap.NORMAL && !Note.Sentence.IsFinished {
+			Result.TLENotes = append(Result.TLENotes, i)
+		}
+	}
+	return Result
+}
@@ -1,11 +1,14 @@
 package body
 
 import (
+	"fmt"
 	"musicaltyper-go/game/beatmap"
 	"musicaltyper-go/game/constants"
 	"musicaltyper-go/game/draw/helper"
 	"musicaltyper-go/game/rank"
+	"musicaltyper-go/game/scoring"
 	"musicaltyper-go/game/view/game/component"
+	"strings"
 
 	"musicaltyper-go/game/draw/area"
 	"musicaltyper-go/game/draw/pos"
@@ -13,8 +16,8 @@ import (
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-// AccGauge draws accuracy guage and player rank
-func AccGauge(CurrentSentence beatmap.Sentence, achievementRate float64, rank rank.Rank) component.Drawable {
+// AccGauge draws accuracy guage and player rank, with what is needed for the next rank if Projection isn't nil
+func AccGauge(CurrentSentence beatmap.Sentence, achievementRate float64, rank rank.Rank, Projection *scoring.Projection) component.Drawable {
 	return func(Renderer *sdl.Renderer) {
 		RankPosX := int(constants.WindowWidth * achievementRate)
 
@@ -27,9 +30,40 @@ func AccGauge(CurrentSentence beatmap.Sentence, achievementRate float64, rank ra
 			helper.DrawFillRect(Renderer, constants.GreenThickColor, GaugeArea)
 		}
 		//正解率ゲージの上に出るランク
-		helper.DrawText(Renderer,
+		RankTextWidth, _ := helper.DrawText(Renderer,
 			pos.FromXY(RankPosX, 168),
 			helper.RightAlign, helper.SystemFont,
-			rank.Text(), constants.TypedTextColor)
+			rank.Text(), rank.Color())
+
+		NextRank, Exists := rank.GetNextRank()
+		if Projection == nil || !Exists {
+			return
+		}
+		//ランクの文字と重ならない広い方に出す
+		Text := NextRank.Text() + "まで " + projectionText(*Projection)
+		if RankPosX > constants.WindowWidth/2 {
+			helper.DrawTextWithoutCache(Renderer, pos.FromXY(RankPosX-RankTextWidth-8, 168), helper.RightAlign, helper.SystemFont, Text, NextRank.Color())
+		} else {
+			helper.DrawTextWithoutCache(Renderer, pos.FromXY(RankPosX+8, 168), helper.LeftAlign, helper.SystemFont, Text, NextRank.Color())
+		}
+	}
+}
+
+// projectionText tells accuracy needed at current speed, or speed needed at current accuracy
+func projectionText(Projection scoring.Projection) string {
+	if !Projection.Reachable {
+		return "届かない"
+	}
+
+	Texts := make([]string, 0, 2)
+	if Projection.Accuracy > 0 {
+		Texts = append(Texts, fmt.Sprintf("正解率%.1f%%", Projection.Accuracy*100))
+	}
+	if Projection.Speed > 0 {
+		Texts = append(Texts, fmt.Sprintf("%.2fkey/s", Projection.Speed))
+	}
+	if len(Texts) == 0 {
+		return "正確さと速さの両方が必要"
 	}
+	return strings.Join(Texts, " または ")
 }
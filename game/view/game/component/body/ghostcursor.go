@@ -0,0 +1,52 @@
+package body
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/view/game/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+var (
+	ghostCursorColor = constants.BlueThickColor.WithTransparency(0.6)
+)
+
+// GhostCursor draws faded cursor on roman text where ghost is typing, relative to player's cursor.
+// If ghost is on another sentence, how many sentences it leads by is drawn at the side instead.
+func GhostCursor(isDisabled bool, sentenceDiff, diff int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if isDisabled {
+			return
+		}
+
+		switch {
+		case sentenceDiff > 0:
+			helper.DrawText(Renderer,
+				pos.FromXY(constants.WindowWidth-constants.Margin, romaPos.Y()),
+				helper.RightAlign, helper.SystemFont,
+				fmt.Sprintf("GHOST %d文先 >>", sentenceDiff), constants.BlueThickColor)
+			return
+
+		case sentenceDiff < 0:
+			helper.DrawText(Renderer,
+				pos.FromXY(constants.Margin, romaPos.Y()),
+				helper.LeftAlign, helper.SystemFont,
+				fmt.Sprintf("<< GHOST %d文後ろ", -sentenceDiff), constants.BlueThickColor)
+			return
+		}
+
+		//等幅フォントなので1文字分の幅で位置を決める
+		CharSize := helper.GetTextSize(Renderer, helper.FullFont, "a", constants.TextColor)
+		X := romaPos.X() + diff*CharSize.W()
+
+		ghostCursorColor.ApplyColor(Renderer)
+		Renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+		Renderer.FillRect(area.FromXYWH(X, romaPos.Y(), 2, CharSize.H()).ToRect())
+		Renderer.FillRect(area.FromXYWH(X, romaPos.Y()+CharSize.H()-2, CharSize.W(), 2).ToRect())
+		Renderer.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+	}
+}
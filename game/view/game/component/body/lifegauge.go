@@ -0,0 +1,28 @@
+package body
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/view/game/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// LifeGauge draws remaining life above time gauge
+func LifeGauge(isDisabled bool, life float64, FrameCount int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if isDisabled {
+			return
+		}
+
+		//残り3割を切ったら赤色で点滅
+		Color := constants.GreenThickColor
+		if life < 0.3 && FrameCount%20 < 10 {
+			Color = constants.RedColor
+		}
+
+		helper.DrawFillRect(Renderer, backgroundColor, area.FromXYWH(0, 56, constants.WindowWidth, 4))
+		helper.DrawFillRect(Renderer, Color, area.FromXYWH(0, 56, int(constants.WindowWidth*life), 4))
+	}
+}
@@ -0,0 +1,21 @@
+package body
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/view/game/component"
+
+	"musicaltyper-go/game/draw/pos"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ModsText draws enabled gameplay modifiers at right of lyrics
+func ModsText(Mods string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Mods == "" {
+			return
+		}
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-5, 62), helper.RightAlign, helper.SystemFont, Mods, constants.RedColor.Darker(50))
+	}
+}
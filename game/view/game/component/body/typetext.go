@@ -17,16 +17,20 @@ var (
 	lyricPos    = pos.FromXY(constants.Margin-12, 60)
 )
 
-// TypeText draws hiragana, roman, and japanese lyrics text
-func TypeText(CurrentSentence beatmap.Sentence) component.Drawable {
+// TypeText draws hiragana, roman, and japanese lyrics text. Hidden lines are not drawn.
+func TypeText(CurrentSentence beatmap.Sentence, isHiraganaHidden, isRomaHidden bool) component.Drawable {
 	return func(Renderer *sdl.Renderer) {
 		//ひらがな
-		helper.DrawText(Renderer, hiraganaPos, helper.RightAlign, helper.JapaneseFont, CurrentSentence.GetTypedText(), constants.TypedTextColor)
-		helper.DrawText(Renderer, hiraganaPos, helper.LeftAlign, helper.JapaneseFont, CurrentSentence.GetRemainingText(), constants.RemainingTextColor)
+		if !isHiraganaHidden {
+			helper.DrawText(Renderer, hiraganaPos, helper.RightAlign, helper.JapaneseFont, CurrentSentence.GetTypedText(), constants.TypedTextColor)
+			helper.DrawText(Renderer, hiraganaPos, helper.LeftAlign, helper.JapaneseFont, CurrentSentence.GetRemainingText(), constants.RemainingTextColor)
+		}
 
 		//ローマ字
-		helper.DrawText(Renderer, romaPos, helper.RightAlign, helper.FullFont, CurrentSentence.GetTypedRoma(), constants.TypedTextColor)
-		helper.DrawText(Renderer, romaPos, helper.LeftAlign, helper.FullFont, CurrentSentence.GetRemainingRoma(), constants.RemainingTextColor)
+		if !isRomaHidden {
+			helper.DrawText(Renderer, romaPos, helper.RightAlign, helper.FullFont, CurrentSentence.GetTypedRoma(), constants.TypedTextColor)
+			helper.DrawText(Renderer, romaPos, helper.LeftAlign, helper.FullFont, CurrentSentence.GetRemainingRoma(), constants.RemainingTextColor)
+		}
 
 		//歌詞
 		helper.DrawText(Renderer, lyricPos, helper.LeftAlign, helper.FullFont, CurrentSentence.OriginalSentence, constants.LyricTextColor)
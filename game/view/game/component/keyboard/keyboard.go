@@ -12,8 +12,8 @@ import (
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-// Keyboard draws virtual keyboard
-func Keyboard(isDisabled, isInputDisabled bool, currentSentence beatmap.Sentence) component.Drawable {
+// Keyboard draws virtual keyboard, highlighting next key unless isHighlightDisabled
+func Keyboard(isDisabled, isInputDisabled, isHighlightDisabled bool, currentSentence beatmap.Sentence) component.Drawable {
 	return func(Renderer *sdl.Renderer) {
 		if isDisabled {
 			return
@@ -21,6 +21,8 @@ func Keyboard(isDisabled, isInputDisabled bool, currentSentence beatmap.Sentence
 
 		if isInputDisabled {
 			drawDisabledKeyboard(Renderer, "", color.FromRGB(192, 192, 192))
+		} else if isHighlightDisabled {
+			drawKeyboard(Renderer, "")
 		} else {
 			drawKeyboard(Renderer, helper.Substring(currentSentence.GetRemainingRoma(), 0, 1))
 		}
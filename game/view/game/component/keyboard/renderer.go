@@ -109,3 +109,17 @@ func GetKeyPos(key string) pos.Pos {
 	}
 	return pos.FromXY(0, 0)
 }
+
+// KeyArea calculates area of the key on virtual keyboard. ok is false if the key isn't on it.
+func KeyArea(key string) (Result area.Area, ok bool) {
+	Size := keySize + keyMargin
+	for i, v := range KeyboardKeys {
+		Index := strings.Index(v, key)
+		if Index != -1 {
+			x := (constants.WindowWidth-Size*len(v))/2 + Index*Size
+			y := startY + i*Size
+			return area.FromXYWH(x, y, keySize, keySize), true
+		}
+	}
+	return Result, false
+}
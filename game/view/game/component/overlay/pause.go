@@ -0,0 +1,65 @@
+package overlay
+
+import (
+	"fmt"
+	"math"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/color"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/view/game/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+var (
+	shadeColor        = color.FromRGBA(0, 0, 0, 140)
+	menuTextColor     = constants.BackgroundColor.Brighter(255)
+	selectedItemColor = constants.GreenThinColor
+)
+
+// shade darkens whole screen
+func shade(Renderer *sdl.Renderer) {
+	shadeColor.ApplyColor(Renderer)
+	Renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	Renderer.FillRect(area.FromXYWH(0, 0, constants.WindowWidth, constants.WindowHeight).ToRect())
+	Renderer.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+}
+
+// PauseMenu draws pause menu over the game screen
+func PauseMenu(Items []string, Selected int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		shade(Renderer)
+
+		helper.DrawText(Renderer,
+			pos.FromXY(constants.WindowWidth/2, 120),
+			helper.Center, helper.BigFont,
+			"PAUSE", menuTextColor)
+
+		for i, v := range Items {
+			Color := menuTextColor
+			Text := v
+			if i == Selected {
+				Color = selectedItemColor
+				Text = "> " + v + " <"
+			}
+			helper.DrawText(Renderer,
+				pos.FromXY(constants.WindowWidth/2, 240+50*i),
+				helper.Center, helper.FullFont,
+				Text, Color)
+		}
+	}
+}
+
+// Countdown draws remaining seconds until resuming
+func Countdown(Remaining float64) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		shade(Renderer)
+
+		helper.DrawText(Renderer,
+			pos.FromXY(constants.WindowWidth/2, 200),
+			helper.Center, helper.BigFont,
+			fmt.Sprintf("%d", int(math.Ceil(Remaining))), menuTextColor)
+	}
+}
@@ -0,0 +1,23 @@
+package overlay
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/view/game/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// SkipHint tells the key to skip at right bottom of the game screen. Draws nothing if Text is empty.
+func SkipHint(Text string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Text == "" {
+			return
+		}
+		helper.DrawText(Renderer,
+			pos.FromXY(constants.WindowWidth-constants.Margin, constants.WindowHeight-30),
+			helper.RightAlign, helper.SystemFont,
+			Text, constants.TextColor)
+	}
+}
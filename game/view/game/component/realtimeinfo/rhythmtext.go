@@ -0,0 +1,23 @@
+package realtimeinfo
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/speed"
+	"musicaltyper-go/game/view/game/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// RhythmText draws reaction to the latest sentence, spread of key intervals, and speed excluding reaction
+func RhythmText(Rhythm speed.RhythmStats) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawTextWithoutCache(Renderer,
+			pos.FromXY(constants.WindowWidth-constants.Margin, 510),
+			helper.RightAlign, helper.SystemFont,
+			fmt.Sprintf("反応 %4.2fs / ゆらぎ %3.0fms / 初速抜き %4.2f", Rhythm.LastReaction, Rhythm.IntervalSpread*1000, Rhythm.TypingKPS),
+			constants.TypedTextColor)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"musicaltyper-go/game/draw/area"
 	"musicaltyper-go/game/draw/helper"
 	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/speed"
 	"musicaltyper-go/game/view/game/component"
 
 	"github.com/veandco/go-sdl2/sdl"
@@ -17,16 +18,22 @@ var (
 )
 
 // SpeedGauge draws players's typing speed by text and color
-func SpeedGauge(typingSpeed float64, FrameCount int) component.Drawable {
+func SpeedGauge(typingSpeed speed.Stats, FrameCount int) component.Drawable {
 	return func(Renderer *sdl.Renderer) {
 		helper.DrawText(Renderer,
 			pos.FromXY(constants.Margin, 382),
 			helper.LeftAlign, helper.SystemFont,
 			"タイピング速度", constants.TypedTextColor)
 
+		helper.DrawTextWithoutCache(Renderer,
+			pos.FromXY(constants.WindowWidth-constants.Margin, 382),
+			helper.RightAlign, helper.SystemFont,
+			fmt.Sprintf("瞬間 %4.2f / 平均 %4.2f (%3.0f KPM)", typingSpeed.Instant, typingSpeed.Average, speed.ToKPM(typingSpeed.Average)),
+			constants.TypedTextColor)
+
 		Area := area.FromXYWH(constants.Margin, 405, constants.WindowWidth-constants.Margin*2, 20)
 
-		if typingSpeed > 4 {
+		if typingSpeed.Window > 4 {
 			//4key/secを超えていたら、赤色でアニメーションs
 			Color := constants.RedColor
 			if !(FrameCount%10 < 5) {
@@ -37,7 +44,7 @@ func SpeedGauge(typingSpeed float64, FrameCount int) component.Drawable {
 			//そうでなければ普通に描画。
 			helper.DrawFillRect(Renderer, constants.GreenThinColor, Area)
 
-			GaugeWidth := int(typingSpeed / 4.0 * float64(constants.WindowWidth-constants.Margin*2))
+			GaugeWidth := int(typingSpeed.Window / 4.0 * float64(constants.WindowWidth-constants.Margin*2))
 			helper.DrawFillRect(Renderer, normalSpeedGaugeForegroundColor,
 				area.FromXYWH(constants.Margin, 405, GaugeWidth, 20),
 			)
@@ -45,7 +52,7 @@ func SpeedGauge(typingSpeed float64, FrameCount int) component.Drawable {
 		helper.DrawText(Renderer,
 			pos.FromXY(constants.WindowWidth/2, 402),
 			helper.Center, helper.SystemFont,
-			fmt.Sprintf("%4.2f Char/sec", typingSpeed), constants.TextColor,
+			fmt.Sprintf("%4.2f Char/sec", typingSpeed.Window), constants.TextColor,
 		)
 	}
 }
@@ -0,0 +1,27 @@
+package top
+
+import (
+	"fmt"
+	Constants "musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/view/game/component"
+
+	"musicaltyper-go/game/draw/pos"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// GhostDiff draws point difference against ghost next to score
+func GhostDiff(isDisabled bool, Diff int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if isDisabled {
+			return
+		}
+
+		Color := Constants.GreenThickColor
+		if Diff < 0 {
+			Color = Constants.RedColor.Darker(50)
+		}
+		helper.DrawText(Renderer, pos.FromXY(150, 27), helper.LeftAlign, helper.FullFont, fmt.Sprintf("%+d", Diff), Color)
+	}
+}
@@ -0,0 +1,18 @@
+package top
+
+import (
+	Constants "musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/view/game/component"
+
+	"musicaltyper-go/game/draw/pos"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PlaybackStatus draws status of playback next to score
+func PlaybackStatus(Status string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawTextWithoutCache(Renderer, pos.FromXY(150, 33), helper.LeftAlign, helper.SystemFont, Status, Constants.RedColor.Darker(50))
+	}
+}
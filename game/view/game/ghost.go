@@ -0,0 +1,115 @@
+package mainview
+
+import (
+	"fmt"
+	"musicaltyper-go/game/beatmap"
+	Constants "musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/replay"
+	"musicaltyper-go/game/view/game/component/effects"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ghost plays back a previous replay silently alongside the player
+type ghost struct {
+	state       *GameState
+	replay      *replay.Replay
+	nextEvent   int
+	nextSection int
+}
+
+func newGhost(Map *beatmap.Beatmap, Replay *replay.Replay) *ghost {
+	Logger := logger.NewLogger("Ghost")
+	if Replay.IsForOlderVersion(Map) {
+		Logger.Warn("The ghost replay was recorded on an older version of the beatmap.")
+	} else if !Replay.IsFor(Map) {
+		Logger.Warn("The ghost replay was recorded on another beatmap.")
+	}
+
+	return &ghost{
+		state:  newReplayState(Map, Replay),
+		replay: Replay,
+	}
+}
+
+// newReplayState makes silent GameState to simulate the replay on fresh copy of the beatmap
+func newReplayState(Map *beatmap.Beatmap, Replay *replay.Replay) *GameState {
+	State := NewGameState(Map.Clone())
+	State.SetRate(Replay.Rate)
+	State.Mods = Replay.Mods
+	State.Silent = true
+	return State
+}
+
+// update feeds recorded key inputs until Now
+func (g *ghost) update(Now float64) {
+	for g.nextEvent < len(g.replay.Events) && g.replay.Events[g.nextEvent].Time <= Now {
+		Event := g.replay.Events[g.nextEvent]
+		g.state.Update(Event.Time)
+		g.state.ParseKeyInput(nil, sdl.Keycode(Event.Key), true)
+		g.nextEvent++
+	}
+	g.state.Update(Now)
+}
+
+// pointDiff returns how many points player leads ghost by, as shown with rate multiplier
+func (g *ghost) pointDiff(Player *GameState) int {
+	return Player.GetScore() - g.state.GetScore()
+}
+
+// cursorDiff returns how many sentences and roman characters ghost leads player by.
+// Characters are counted only while both are on the same sentence.
+func (g *ghost) cursorDiff(Player *GameState) (int, int) {
+	var (
+		Index      = Player.CurrentSentenceIndex
+		GhostIndex = g.state.CurrentSentenceIndex
+		Notes      = Player.Beatmap.Notes
+	)
+	if GhostIndex != Index {
+		return countSentences(Notes, Index, GhostIndex) - countSentences(Notes, GhostIndex, Index), 0
+	}
+	if Notes[Index].Type != beatmap.NORMAL {
+		return 0, 0
+	}
+	var (
+		PlayerTyped = Notes[Index].Sentence.GetTypedRoma()
+		GhostTyped  = g.state.Beatmap.Notes[Index].Sentence.GetTypedRoma()
+	)
+	return 0, len(GhostTyped) - len(PlayerTyped)
+}
+
+// countSentences counts normal notes from index From until To, or 0 if To isn't after From
+func countSentences(Notes []*beatmap.Note, From, To int) int {
+	Result := 0
+	for i := From; i < To; i++ {
+		if Notes[i].Type == beatmap.NORMAL {
+			Result++
+		}
+	}
+	return Result
+}
+
+// checkSplit flashes point difference when a section begins
+func (g *ghost) checkSplit(Player *GameState) {
+	Sections := Player.Beatmap.Sections
+	for g.nextSection < len(Sections) && Sections[g.nextSection].Time <= Player.CurrentTime {
+		if g.nextSection > 0 {
+			Diff := g.pointDiff(Player)
+			Color := Constants.GreenThickColor
+			if Diff < 0 {
+				Color = Constants.RedColor.Darker(50)
+			}
+
+			AddEffector(FOREGROUND, 90, effects.NewSlideFadeoutText(
+				fmt.Sprintf("%s %+d", Sections[g.nextSection].ID, Diff),
+				Color,
+				helper.FullFont,
+				pos.FromXY(170, -222), 20,
+			))
+		}
+		g.nextSection++
+	}
+}
@@ -0,0 +1,134 @@
+package mainview
+
+import (
+	"musicaltyper-go/game/view"
+	"musicaltyper-go/game/view/game/component/overlay"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	resumeCountdownSeconds = 3
+)
+
+// PauseState is a kind of pause state of game view
+type PauseState uint8
+
+const (
+	// PLAYING means game is running
+	PLAYING PauseState = iota
+	// PAUSED means pause menu is shown
+	PAUSED
+	// COUNTDOWN means game will be resumed soon
+	COUNTDOWN
+)
+
+type pauseMenuItem uint8
+
+const (
+	resumeItem pauseMenuItem = iota
+	retryItem
+	quitItem
+)
+
+var (
+	pauseMenuTexts = []string{"再開", "リトライ", "終了"}
+)
+
+// pause halts music, song time and effects, and shows pause menu
+func (v *gameView) pause() {
+	if v.pauseState == PAUSED {
+		return
+	}
+	v.clock.Pause()
+	v.state.SetPaused(true)
+	v.pauseState = PAUSED
+	v.pauseMenuIndex = int(resumeItem)
+}
+
+// beginResume starts countdown to resume
+func (v *gameView) beginResume() {
+	v.pauseState = COUNTDOWN
+	v.countdownStartTime = time.Now()
+}
+
+// resume restarts music and song time
+func (v *gameView) resume() {
+	v.clock.Resume()
+	v.state.SetPaused(false)
+	v.pauseState = PLAYING
+}
+
+// handlePauseMenu handles key input while pause menu is shown
+func (v *gameView) handlePauseMenu(key sdl.Keycode) {
+	switch key {
+	case sdl.K_ESCAPE:
+		v.beginResume()
+
+	case sdl.K_UP:
+		v.pauseMenuIndex = (v.pauseMenuIndex + len(pauseMenuTexts) - 1) % len(pauseMenuTexts)
+
+	case sdl.K_DOWN:
+		v.pauseMenuIndex = (v.pauseMenuIndex + 1) % len(pauseMenuTexts)
+
+	case sdl.K_RETURN:
+		switch pauseMenuItem(v.pauseMenuIndex) {
+		case resumeItem:
+			v.beginResume()
+
+		case retryItem:
+			v.retry()
+
+		case quitItem:
+			if v.practice != nil {
+				//Finishing practice shows its result.
+				v.result = v.makeResult()
+				return
+			}
+			v.saveReplay()
+			v.closing = true
+		}
+	}
+}
+
+// retry stops this play and starts the same chart from the beginning
+func (v *gameView) retry() {
+	v.saveReplay()
+	v.nextView = v.restart()
+}
+
+// updatePause resumes game if countdown has finished
+func (v *gameView) updatePause() {
+	if v.pauseState == COUNTDOWN && time.Now().Sub(v.countdownStartTime).Seconds() >= resumeCountdownSeconds {
+		v.resume()
+	}
+}
+
+// drawPause draws pause overlay
+func (v *gameView) drawPause(Renderer *sdl.Renderer) {
+	switch v.pauseState {
+	case PAUSED:
+		overlay.PauseMenu(pauseMenuTexts, v.pauseMenuIndex)(Renderer)
+
+	case COUNTDOWN:
+		Remaining := resumeCountdownSeconds - time.Now().Sub(v.countdownStartTime).Seconds()
+		overlay.Countdown(Remaining)(Renderer)
+	}
+}
+
+// pollNextView returns event to change view or go back when the view requested
+func (v *gameView) pollNextView() view.Event {
+	if v.closing {
+		v.closing = false
+		return &view.PopViewEvent{}
+	}
+	if v.nextView == nil {
+		return nil
+	}
+	ev := view.ChangeViewEvent{
+		ToChangeView: v.nextView,
+	}
+	v.nextView = nil
+	return &ev
+}
@@ -0,0 +1,179 @@
+package mainview
+
+import (
+	"fmt"
+	"math"
+	"musicaltyper-go/game/beatmap"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	practiceRateStep = 0.1
+	// PracticeMinRate is the slowest playback rate in practice mode
+	PracticeMinRate = 0.5
+	// PracticeMaxRate is the fastest playback rate in practice mode
+	PracticeMaxRate = 1.0
+)
+
+// Practice has settings of practice mode, which loops a part of the chart
+type Practice struct {
+	// Section is index of Beatmap.Sections to loop
+	Section int
+
+	// FirstNote and LastNote are inclusive range of note indices to loop.
+	// If LastNote is smaller than FirstNote, Section is used instead.
+	FirstNote int
+	LastNote  int
+
+	// Rate is playback rate of the song
+	Rate float64
+}
+
+// UsesNoteRange returns whether the practice loops note range instead of section
+func (p Practice) UsesNoteRange() bool {
+	return p.LastNote >= p.FirstNote
+}
+
+type practiceState struct {
+	Practice
+
+	startTime float64
+	endTime   float64
+	firstNote int
+	lastNote  int
+	// target is name of looped part to show
+	target string
+}
+
+// startPractice sets up practice mode and jumps to the beginning of loop
+func (v *gameView) startPractice(Practice Practice) {
+	Practice.Rate = math.Max(PracticeMinRate, math.Min(PracticeMaxRate, Practice.Rate))
+
+	v.practice = &practiceState{Practice: Practice}
+	v.state.Life = nil
+	v.setPracticeRate(Practice.Rate)
+	v.restartPracticeLoop()
+}
+
+// restartPracticeLoop clears typing state of looped notes and jumps to the beginning of loop
+func (v *gameView) restartPracticeLoop() {
+	p := v.practice
+	Map := v.state.Beatmap
+	p.locate(Map)
+
+	for i := p.firstNote; i <= p.lastNote; i++ {
+		Map.Notes[i].Reset()
+	}
+
+	ClearEffectors()
+	v.music.Seek(p.startTime)
+	v.state.Seek(p.startTime)
+	if Index := v.state.CurrentSentenceIndex; Index < p.firstNote {
+		//No note starts at the beginning of loop. Previous note isn't a part of practice, so it must not be judged as TLE.
+		if Sentence := Map.Notes[Index].Sentence; Sentence != nil {
+			Sentence.IsFinished = true
+		}
+		v.state.setInputDisabled(true)
+	}
+}
+
+// updatePractice goes back to the beginning when the loop has ended
+func (v *gameView) updatePractice() {
+	if v.state.CurrentTime >= v.practice.endTime {
+		v.restartPracticeLoop()
+	}
+}
+
+// setPracticeRate changes playback rate of the song
+func (v *gameView) setPracticeRate(Rate float64) {
+	//Avoid accumulating error of float steps, e.g. 0.7999999
+	Rate = math.Round(Rate*100) / 100
+
+	v.practice.Rate = Rate
+	v.music.SetRate(Rate)
+	v.state.SetRate(Rate)
+}
+
+// handlePracticeKey handles keys to control practice. Returns false if the key isn't for practice.
+func (v *gameView) handlePracticeKey(key sdl.Keycode) bool {
+	p := v.practice
+	switch key {
+	case sdl.K_LEFT, sdl.K_RIGHT:
+		if !p.UsesNoteRange() && len(v.state.Beatmap.Sections) > 0 {
+			Count := len(v.state.Beatmap.Sections)
+			if key == sdl.K_LEFT {
+				p.Section = (p.Section + Count - 1) % Count
+			} else {
+				p.Section = (p.Section + 1) % Count
+			}
+		}
+		v.restartPracticeLoop()
+
+	case sdl.K_UP:
+		v.setPracticeRate(math.Min(PracticeMaxRate, p.Rate+practiceRateStep))
+
+	case sdl.K_DOWN:
+		v.setPracticeRate(math.Max(PracticeMinRate, p.Rate-practiceRateStep))
+
+	default:
+		return false
+	}
+	return true
+}
+
+// locate decides time and notes to loop
+func (p *practiceState) locate(Map *beatmap.Beatmap) {
+	var (
+		Notes    = Map.Notes
+		Sections = Map.Sections
+		LastNote = len(Notes) - 1 // END
+	)
+
+	if p.UsesNoteRange() || len(Sections) == 0 {
+		//Without sections, whole of the chart is looped.
+		p.firstNote, p.lastNote = 0, LastNote-1
+		if p.UsesNoteRange() {
+			p.firstNote = clampIndex(p.FirstNote, LastNote-1)
+			p.lastNote = clampIndex(p.LastNote, LastNote-1)
+		}
+		p.startTime = Notes[p.firstNote].Time
+		p.endTime = Notes[p.lastNote+1].Time
+		p.target = fmt.Sprintf("#%d-%d", p.firstNote, p.lastNote)
+		return
+	}
+
+	p.Section = clampIndex(p.Section, len(Sections)-1)
+	p.startTime = Sections[p.Section].Time
+	p.endTime = Notes[LastNote].Time
+	if p.Section+1 < len(Sections) {
+		p.endTime = Sections[p.Section+1].Time
+	}
+
+	p.target = "@" + Sections[p.Section].ID
+
+	p.firstNote, p.lastNote = LastNote, -1
+	for i := 0; i < LastNote; i++ {
+		if Notes[i].Time >= p.startTime && Notes[i].Time < p.endTime {
+			if p.lastNote < 0 {
+				p.firstNote = i
+			}
+			p.lastNote = i
+		}
+	}
+}
+
+// status returns text to show state of practice
+func (p *practiceState) status() string {
+	return fmt.Sprintf("PRACTICE %s x%.2f (←→:区間 ↑↓:速度)", p.target, p.Rate)
+}
+
+func clampIndex(Index, Max int) int {
+	if Index < 0 {
+		return 0
+	}
+	if Index > Max {
+		return Max
+	}
+	return Index
+}
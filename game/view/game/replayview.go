@@ -0,0 +1,146 @@
+package mainview
+
+import (
+	"fmt"
+	"math"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/replay"
+	"musicaltyper-go/game/view"
+	Top "musicaltyper-go/game/view/game/component/top"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	replaySeekStep = 5.0
+	replayRateStep = 0.25
+	replayMinRate  = 0.5
+	replayMaxRate  = 2.0
+)
+
+type replayView struct {
+	*gameView
+
+	original  *beatmap.Beatmap
+	replay    *replay.Replay
+	nextEvent int
+}
+
+// NewReplayView makes view which plays back recorded key inputs on the beatmap
+func NewReplayView(Map *beatmap.Beatmap, Replay *replay.Replay) view.View {
+	Logger := logger.NewLogger("NewReplayView")
+	if Replay.IsForOlderVersion(Map) {
+		Logger.Warn("The replay was recorded on an older version of the beatmap. Playback may differ from original play.")
+	} else if !Replay.IsFor(Map) {
+		Logger.Warn("The replay was recorded on another beatmap. Playback may differ from original play.")
+	}
+	if Replay.RomaTableID != beatmap.RomaTableID {
+		Logger.Warn("The replay was recorded with another roman table. Playback may differ from original play.")
+	}
+
+	Original := Map.Clone()
+	Music := loadMusic(Map)
+	Music.SetRate(Replay.Rate)
+
+	Result := &replayView{
+		gameView: newGameView(Map, Music),
+		original: Original,
+		replay:   Replay,
+	}
+	Result.state.SetRate(Replay.Rate)
+	Result.state.Mods = Replay.Mods
+	Result.restart = func() view.View {
+		return NewReplayView(Original.Clone(), Replay)
+	}
+	return Result
+}
+
+func (v *replayView) GetName() string {
+	return "ReplayView"
+}
+
+func (v *replayView) HandleSDLEvent(renderer *sdl.Renderer, event sdl.Event) bool {
+	switch e := event.(type) {
+	case *sdl.KeyboardEvent:
+		if e.Type != sdl.KEYDOWN {
+			return true
+		}
+
+		switch e.Keysym.Sym {
+		case sdl.K_ESCAPE:
+			v.closing = true
+
+		case sdl.K_SPACE:
+			if v.music.IsPaused() {
+				v.music.Resume()
+				v.pauseState = PLAYING
+			} else {
+				v.music.Pause()
+				v.pauseState = PAUSED
+			}
+
+		case sdl.K_LEFT:
+			v.seek(v.music.Now() - replaySeekStep)
+
+		case sdl.K_RIGHT:
+			v.seek(v.music.Now() + replaySeekStep)
+
+		case sdl.K_UP:
+			v.music.SetRate(math.Min(replayMaxRate, v.music.Rate()+replayRateStep))
+
+		case sdl.K_DOWN:
+			v.music.SetRate(math.Max(replayMinRate, v.music.Rate()-replayRateStep))
+
+		case sdl.K_LSHIFT, sdl.K_RSHIFT:
+			v.printingNextLyrics = !v.printingNextLyrics
+		}
+	}
+	return true
+}
+
+// seek rebuilds game state by simulating recorded key inputs until the position
+func (v *replayView) seek(Position float64) {
+	Position = math.Max(0, Position)
+
+	State := newReplayState(v.original, v.replay)
+	Index := 0
+	for ; Index < len(v.replay.Events) && v.replay.Events[Index].Time <= Position; Index++ {
+		Event := v.replay.Events[Index]
+		State.Update(Event.Time)
+		State.ParseKeyInput(nil, sdl.Keycode(Event.Key), true)
+	}
+	State.Update(Position)
+	State.Silent = false
+
+	ClearEffectors()
+	v.state = State
+	v.nextEvent = Index
+	v.music.Seek(Position)
+}
+
+func (v *replayView) Draw(Renderer *sdl.Renderer) {
+	Now := v.music.Now()
+	for v.nextEvent < len(v.replay.Events) && v.replay.Events[v.nextEvent].Time <= Now {
+		Event := v.replay.Events[v.nextEvent]
+		v.typeKey(Renderer, sdl.Keycode(Event.Key), Event.Time)
+		v.nextEvent++
+	}
+
+	if !v.render(Renderer) {
+		return
+	}
+
+	Length := v.state.Beatmap.Notes[len(v.state.Beatmap.Notes)-1].Time
+	Status := fmt.Sprintf("REPLAY x%.2f %s / %s", v.music.Rate(), formatSongTime(Now), formatSongTime(Length))
+	if v.music.IsPaused() {
+		Status += " (一時停止)"
+	}
+	Top.PlaybackStatus(Status)(Renderer)
+
+	Renderer.Present()
+}
+
+func formatSongTime(Time float64) string {
+	return fmt.Sprintf("%02d:%02d", int(Time)/60, int(Time)%60)
+}
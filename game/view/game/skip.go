@@ -0,0 +1,118 @@
+package mainview
+
+import "musicaltyper-go/game/beatmap"
+
+const (
+	// SkipPropertyName is name of beatmap property which decides what can be skipped
+	SkipPropertyName = "skip"
+
+	// skipSettingAll allows both of intro and outro skip. It's default.
+	skipSettingAll = "all"
+	// skipSettingIntro allows only intro skip
+	skipSettingIntro = "intro"
+	// skipSettingOutro allows only outro skip
+	skipSettingOutro = "outro"
+	// skipSettingNone disallows any skip
+	skipSettingNone = "none"
+
+	// skipFadeSeconds is length of fading music out before result
+	skipFadeSeconds = 1.0
+	// introLeadSeconds is time left before the first sentence after intro skip
+	introLeadSeconds = 2.0
+	// introSkipMinSeconds is the shortest intro which can be skipped
+	introSkipMinSeconds = 5.0
+)
+
+// canSkip returns whether the chart allows the kind of skip
+func canSkip(Map *beatmap.Beatmap, Kind string) bool {
+	Setting, Exists := Map.Properties[SkipPropertyName]
+	if !Exists {
+		Setting = skipSettingAll
+	}
+	return Setting == skipSettingAll || Setting == Kind
+}
+
+// firstNormalNote returns index of the first NORMAL note, or -1 if none
+func firstNormalNote(Map *beatmap.Beatmap) int {
+	for i, v := range Map.Notes {
+		if v.Type == beatmap.NORMAL {
+			return i
+		}
+	}
+	return -1
+}
+
+// canSkipIntro returns whether the first sentence is far enough to jump to
+func (v *gameView) canSkipIntro() bool {
+	Map := v.state.Beatmap
+	First := firstNormalNote(Map)
+	if v.practice != nil || First < 0 || v.state.CurrentSentenceIndex >= First || !canSkip(Map, skipSettingIntro) {
+		return false
+	}
+	return Map.Notes[First].Time-v.state.CurrentTime > introSkipMinSeconds
+}
+
+// canSkipOutro returns whether no sentences remain to be typed
+func (v *gameView) canSkipOutro() bool {
+	Map := v.state.Beatmap
+	if v.practice != nil || v.skippingOutro || !canSkip(Map, skipSettingOutro) {
+		return false
+	}
+
+	for _, Note := range Map.Notes[v.state.CurrentSentenceIndex:] {
+		if Note.Type == beatmap.NORMAL && !Note.Sentence.IsFinished {
+			return false
+		}
+	}
+	return true
+}
+
+// skipIntro jumps music and song time to just before the first sentence
+func (v *gameView) skipIntro() {
+	Map := v.state.Beatmap
+	Position := Map.Notes[firstNormalNote(Map)].Time - introLeadSeconds
+
+	v.music.Seek(Position)
+	v.state.Seek(Position)
+}
+
+// skipOutro fades music out, and shows result after that
+func (v *gameView) skipOutro() {
+	v.music.FadeOut(skipFadeSeconds)
+	v.skippingOutro = true
+}
+
+// handleSkipKey skips intro or outro if possible. Returns false if nothing can be skipped.
+func (v *gameView) handleSkipKey() bool {
+	switch {
+	case v.canSkipIntro():
+		v.skipIntro()
+
+	case v.canSkipOutro():
+		v.skipOutro()
+
+	default:
+		return false
+	}
+	return true
+}
+
+// isOutroSkipped returns whether fading out for outro skip has finished. Fading stops while paused, as music does.
+func (v *gameView) isOutroSkipped() bool {
+	return v.skippingOutro && (!v.music.IsFading() || v.music.IsFinished())
+}
+
+// skipHint returns text to tell what can be skipped now
+func (v *gameView) skipHint() string {
+	switch {
+	case v.demo:
+		return ""
+
+	case v.canSkipIntro():
+		return "Enter: イントロをスキップ"
+
+	case v.canSkipOutro():
+		return "Enter: 結果へ"
+	}
+	return ""
+}
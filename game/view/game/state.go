@@ -2,17 +2,23 @@ package mainview
 
 import (
 	"fmt"
-	"math"
 	Beatmap "musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/config"
 	Constants "musicaltyper-go/game/constants"
 	"musicaltyper-go/game/draw/helper"
 	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/life"
+	"musicaltyper-go/game/mistake"
+	"musicaltyper-go/game/mods"
 	Rank "musicaltyper-go/game/rank"
+	"musicaltyper-go/game/scoring"
 	"musicaltyper-go/game/sehelper"
+	"musicaltyper-go/game/speed"
+	"musicaltyper-go/game/timeline"
+	"musicaltyper-go/game/view/game/component"
 	"musicaltyper-go/game/view/game/component/effects"
 	"musicaltyper-go/game/view/game/component/keyboard"
-	"sync"
-	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -24,146 +30,184 @@ type GameState struct {
 	CurrentSentenceIndex int
 	CurrentTime          float64
 
-	Combo        int
-	Point        int
-	PerfectPoint int
+	scoring.Stats
+	Rule      scoring.ScoringRule
+	RankTable *Rank.Table
 
-	TotalCorrectCount int
-	TotalMissCount    int
+	// Life is life gauge, or nil when playing without it
+	Life *life.Gauge
 
 	IsInputDisabled bool
-
-	KeyInputs []time.Time
-
-	KeyInputSpeedMutex sync.Mutex
-	KeyInputSpeedSum   int
-	KeyInputSpeedCount int
-
-	stopTypeSpeedCalcDaemon chan bool
+	IsPaused        bool
+
+	// Silent suppresses effects and sound effects, e.g. while simulating a play
+	Silent bool
+
+	Speed *speed.Tracker
+	// Rhythm measures reaction and consistency of key intervals
+	Rhythm *speed.Rhythm
+	// Rate is playback rate of the song. Typing speed is measured in song time, so it's scaled by this.
+	Rate float64
+	Mods mods.Mod
+
+	// Mistakes records which key was typed where another key was expected
+	Mistakes *mistake.Matrix
+
+	// Sentences records how each sentence was typed, in order of finishing
+	Sentences []judge.SentenceResult
+	// typed is keys correctly typed in the current sentence, and firstKeyTime is song time of the first one
+	typed        []byte
+	firstKeyTime float64
+
+	// Timeline records achievement rate and combo at each judgement
+	Timeline timeline.Timeline
+
+	// Projection is what is needed over remaining notes to reach the next rank, or nil if unknown
+	Projection *scoring.Projection
+	// noteLengths are lengths of the shortest romaji of each note
+	noteLengths []int
 }
 
 // NewGameState makes GameState from Beatmap
 func NewGameState(Map *Beatmap.Beatmap) *GameState {
 	r := new(GameState)
 	r.Beatmap = Map
-	r.KeyInputs = make([]time.Time, 0)
-	r.IsInputDisabled = Map.Notes[0].Type != Beatmap.NORMAL
-	r.stopTypeSpeedCalcDaemon = make(chan bool)
-
-	go calcTypeSpeed(r)
+	r.Rule = scoring.ForBeatmap(Map)
+	r.RankTable = Rank.ForRule(r.Rule.ID())
+	r.Life = life.NewGauge(life.Shape(config.Get().LifeGauge))
+	r.Speed = speed.NewTracker()
+	r.Rhythm = speed.NewRhythm()
+	r.Rate = 1
+	r.Mistakes = mistake.NewMatrix()
+	r.Sentences = make([]judge.SentenceResult, 0)
+	r.Timeline = make(timeline.Timeline, 0)
+	r.noteLengths = make([]int, len(Map.Notes))
+	for i, Note := range Map.Notes {
+		if Note.Type == Beatmap.NORMAL {
+			r.noteLengths[i] = len(Note.Sentence.GetShortestRemainingRoma())
+		}
+	}
+	r.setInputDisabled(Map.Notes[0].Type != Beatmap.NORMAL)
 
 	return r
 }
 
-func calcTypeSpeed(state *GameState) {
-	ticker := time.NewTicker(100 * time.Millisecond)
-	Continue := true
-	DontErase := false
-
-	for Continue {
-		select {
-		case <-state.stopTypeSpeedCalcDaemon:
-			Continue = false
-
-		case <-ticker.C:
-			if !state.IsInputDisabled {
-				state.KeyInputSpeedMutex.Lock()
-
-				now := time.Now()
-				remains := make([]time.Time, 0, len(state.KeyInputs))
-
-				for _, v := range state.KeyInputs {
-					if (now.Sub(v).Milliseconds() < 100) && !DontErase {
-						remains = append(remains, v)
-					}
-				}
-				state.KeyInputs = remains
-				state.KeyInputSpeedSum += len(remains) * 10
-				state.KeyInputSpeedCount++
+// setInputDisabled switches input state, and stops counting typing speed while disabled
+func (s *GameState) setInputDisabled(Disabled bool) {
+	s.IsInputDisabled = Disabled
+	s.Speed.SetActive(s.CurrentTime, !s.IsInputDisabled && !s.IsPaused)
+}
 
-				fmt.Print("[CalcTypeSpeedDaemon] ThisTime:", len(remains)*10, "Average:", float64(state.KeyInputSpeedSum)/float64(state.KeyInputSpeedCount))
+// SetPaused switches pause state, and stops counting typing speed while paused
+func (s *GameState) SetPaused(Paused bool) {
+	s.IsPaused = Paused
+	s.Speed.SetActive(s.CurrentTime, !s.IsInputDisabled && !s.IsPaused)
+}
 
-				if DontErase {
-					fmt.Println(" Didn't Erase.")
-					DontErase = false
-				}
+// SetRate changes playback rate. Typing speed is measured again from now on.
+func (s *GameState) SetRate(Rate float64) {
+	s.Rate = Rate
+	s.Speed = speed.NewTracker()
+	s.Speed.SetActive(s.CurrentTime, !s.IsInputDisabled && !s.IsPaused)
+	s.Rhythm = speed.NewRhythm()
+}
 
-				state.KeyInputSpeedMutex.Unlock()
-			} else {
-				DontErase = true
-			}
+// addEffector adds effector unless the state is silent
+func (s *GameState) addEffector(Pos EffectorPos, Duration int, Effector component.DrawableEffect) {
+	if !s.Silent {
+		AddEffector(Pos, Duration, Effector)
+	}
+}
 
-		}
+// playSE plays sound effect unless the state is silent
+func (s *GameState) playSE(SE sehelper.SEType) {
+	if !s.Silent {
+		sehelper.Play(SE)
 	}
 }
 
-// Update overrides current time and updates current note
+// Update overrides current song time and updates current note
 func (s *GameState) Update(CurrentTime float64) {
 	s.CurrentTime = CurrentTime
-	if len(s.Beatmap.Notes) > s.CurrentSentenceIndex+1 && s.Beatmap.Notes[s.CurrentSentenceIndex+1].Time <= CurrentTime {
+	Index := s.CurrentSentenceIndex
+	for len(s.Beatmap.Notes) > s.CurrentSentenceIndex+1 && s.Beatmap.Notes[s.CurrentSentenceIndex+1].Time <= CurrentTime {
 		fmt.Println("Updated index")
 
 		Note := s.Beatmap.Notes[s.CurrentSentenceIndex]
 		CurrentSentence := Note.Sentence
 		if !CurrentSentence.IsFinished && Note.Type == Beatmap.NORMAL {
-			AddEffector(FOREGROUND, 120, tleTextEffect)
-			AddEffector(BACKGROUND, 15, tleBackgroundEffect)
-			sehelper.Play(sehelper.TleSE)
+			s.recordSentence(judge.TLE, s.Beatmap.Notes[s.CurrentSentenceIndex+1].Time)
+			s.timeOut()
+			s.addEffector(FOREGROUND, 120, tleTextEffect)
+			s.addEffector(BACKGROUND, 15, tleBackgroundEffect)
+			s.playSE(sehelper.TleSE)
 		}
 
 		s.CurrentSentenceIndex++
-		s.IsInputDisabled = s.Beatmap.Notes[s.CurrentSentenceIndex].Type != Beatmap.NORMAL
+		s.typed = s.typed[:0]
+		s.Rhythm.StartSentence()
+		s.setInputDisabled(s.Beatmap.Notes[s.CurrentSentenceIndex].Type != Beatmap.NORMAL)
+	}
+
+	//Remaining notes have changed.
+	if s.CurrentSentenceIndex != Index {
+		s.project()
 	}
 }
 
-// GetAccuracy calculates accuracy
-func (s *GameState) GetAccuracy() float64 {
-	if s.TotalCorrectCount == 0 {
-		return 0
+// Seek moves to the time without judging passed notes
+func (s *GameState) Seek(Time float64) {
+	s.CurrentTime = Time
+	s.CurrentSentenceIndex = 0
+	for len(s.Beatmap.Notes) > s.CurrentSentenceIndex+1 && s.Beatmap.Notes[s.CurrentSentenceIndex+1].Time <= Time {
+		s.CurrentSentenceIndex++
 	}
+	s.typed = s.typed[:0]
+	s.Rhythm.StartSentence()
 
-	return float64(s.TotalCorrectCount) / float64(s.TotalMissCount+s.TotalCorrectCount)
+	Note := s.Beatmap.Notes[s.CurrentSentenceIndex]
+	s.setInputDisabled(Note.Type != Beatmap.NORMAL || Note.Sentence.IsFinished)
 }
 
-// GetAchievementRate calculates achievement rate
+// GetAchievementRate calculates achievement rate by scoring rule
 func (s *GameState) GetAchievementRate(Limit bool) float64 {
-	Acc := s.GetAccuracy()
-	PerfectScore := s.PerfectPoint + s.TotalCorrectCount*45
-	Score := float64(s.Point) * Acc
-	if Score <= 0 {
-		return 0
-	}
+	return s.Rule.GetAchievementRate(&s.Stats, Limit)
+}
 
-	if Limit {
-		Score = math.Min(Score, float64(PerfectScore))
-	}
-	return Score / float64(PerfectScore)
+// GetScore returns point multiplied by playback rate
+func (s *GameState) GetScore() int {
+	return int(float64(s.Point) * scoring.RateMultiplier(s.Rate))
 }
 
 // GetRank decides player rank
 func (s *GameState) GetRank() Rank.Rank {
 	Rate := s.GetAchievementRate(false)
-	return Rank.FromAchievementRate(Rate)
+	return s.RankTable.FromAchievementRate(Rate)
 }
 
-// CountKeyType records time when typed any key
+// CountKeyType records song time when typed any key
 func (s *GameState) CountKeyType() {
-	s.KeyInputSpeedMutex.Lock()
-	defer s.KeyInputSpeedMutex.Unlock()
-
-	s.KeyInputs = append(s.KeyInputs, time.Now())
+	s.Speed.Record(s.CurrentTime)
 }
 
-// GetKeyTypePerSecond calculates typed times per second from records from CountKeyType
+// GetKeyTypePerSecond calculates average typing speed over whole song
 func (s *GameState) GetKeyTypePerSecond() float64 {
-	s.KeyInputSpeedMutex.Lock()
-	defer s.KeyInputSpeedMutex.Unlock()
+	return s.Speed.Average(s.CurrentTime) * s.Rate
+}
 
-	if s.KeyInputSpeedCount == 0 {
-		return 0
-	}
+// GetRhythmStats calculates reaction and consistency of key intervals in real time
+func (s *GameState) GetRhythmStats() speed.RhythmStats {
+	return s.Rhythm.Stats().Scale(s.Rate)
+}
 
-	return float64(s.KeyInputSpeedSum) / float64(s.KeyInputSpeedCount)
+// GetSpeedStats calculates instantaneous, sliding-window and whole-song typing speeds
+func (s *GameState) GetSpeedStats() speed.Stats {
+	Stats := s.Speed.Stats(s.CurrentTime)
+	return speed.Stats{
+		Instant: Stats.Instant * s.Rate,
+		Window:  Stats.Window * s.Rate,
+		Average: Stats.Average * s.Rate,
+	}
 }
 
 // AddPoint decides and adds point with flags
@@ -173,75 +217,184 @@ func (s *GameState) AddPoint(isTypeOK, isThisSentenceEnded bool) (point int) {
 	if isTypeOK {
 		s.TotalCorrectCount++
 		s.Combo++
-
-		point = int(Constants.OneCharPoint * 10 * s.GetKeyTypePerSecond() * float64(s.Combo/10))
-		s.Point += point
-		s.PerfectPoint += Constants.OneCharPoint * 10 * Constants.IdealTypeSpeed * s.Combo / 10
-
-		if isThisSentenceEnded {
-			s.PerfectPoint += Constants.ClearPoint + Constants.PerfectPoint
-			s.Point += Constants.ClearPoint
-			if CurrentSentence.MissCount == 0 {
-				s.Point += Constants.PerfectPoint
-			}
-		} else {
-			CurrentSentence.TypeCount++
-		}
 	} else {
 		s.TotalMissCount++
 		CurrentSentence.MissCount++
-		point = Constants.MissPoint
-		s.Point += point
+	}
+
+	point = s.Rule.AddPoint(&s.Stats, CurrentSentence, isTypeOK, isThisSentenceEnded, s.GetKeyTypePerSecond())
+
+	if !isTypeOK {
 		s.Combo = 0
+	} else if !isThisSentenceEnded {
+		CurrentSentence.TypeCount++
+	}
+
+	if s.Life != nil {
+		switch {
+		case !isTypeOK:
+			s.Life.OnMiss()
+		case isThisSentenceEnded && CurrentSentence.MissCount == 0:
+			s.Life.OnAC()
+		default:
+			s.Life.OnCorrect()
+		}
+	}
+
+	if isTypeOK {
+		s.sample(judge.CORRECT)
+	} else {
+		s.sample(judge.MISS)
 	}
 	return
 }
 
+// sample records achievement rate and combo right after the judgement
+func (s *GameState) sample(Judge judge.Judge) {
+	s.Timeline = append(s.Timeline, timeline.Sample{
+		Time:            s.CurrentTime,
+		AchievementRate: s.GetAchievementRate(false),
+		Combo:           s.Combo,
+		Judge:           Judge,
+	})
+	s.project()
+}
+
+// project forecasts accuracy and speed needed over remaining notes to reach the next rank
+func (s *GameState) project() {
+	s.Projection = nil
+	NextRank, Exists := s.GetRank().GetNextRank()
+	if s.Silent || !Exists || s.TotalCorrectCount == 0 {
+		return
+	}
+
+	Remaining := make([]int, 0, len(s.noteLengths)-s.CurrentSentenceIndex)
+	if Note := s.Beatmap.Notes[s.CurrentSentenceIndex]; Note.Type == Beatmap.NORMAL && !Note.Sentence.IsFinished {
+		Remaining = append(Remaining, len(Note.Sentence.GetShortestRemainingRoma()))
+	}
+	Remaining = append(Remaining, s.noteLengths[s.CurrentSentenceIndex+1:]...)
+
+	Projection := scoring.Project(s.Rule, s.Stats, Remaining, s.GetAccuracy(), s.GetKeyTypePerSecond(), NextRank.BorderRate()/100)
+	s.Projection = &Projection
+}
+
+// IsFailed returns whether life gauge has run out
+func (s *GameState) IsFailed() bool {
+	return s.Life != nil && s.Life.IsEmpty()
+}
+
 // AddTLEPoint adds points when failed to type all
 func (s *GameState) AddTLEPoint() {
 	CurrentSentence := s.Beatmap.Notes[s.CurrentSentenceIndex].Sentence
 	TextLen := len(CurrentSentence.GetRemainingRoma())
 
-	s.Point += Constants.CouldntTypeCount * TextLen
-	s.PerfectPoint += Constants.OneCharPoint*TextLen*40 + Constants.ClearPoint + Constants.PerfectPoint
+	s.Rule.AddTLEPoint(&s.Stats, CurrentSentence, TextLen)
 	s.TotalMissCount += TextLen
 	CurrentSentence.MissCount += TextLen
+	s.onTLE(TextLen)
 }
 
-// ParseKeyInput handles key input event from sdl
-func (s *GameState) ParseKeyInput(renderer *sdl.Renderer, code sdl.Keycode, PrintLyric bool) {
-	if !((code >= 'a' && code <= 'z') || (code >= '0' && code <= '9') || code == '[' || code == ']' || code == ',' || code == '.' || code == ' ' || code == '-') {
+// timeOut judges current sentence which time has run out on, or which was given up. Remaining roman is charged only if the scoring rule does so.
+func (s *GameState) timeOut() {
+	if s.Rule.ChargesTimeout() {
+		s.AddTLEPoint()
 		return
 	}
+	s.onTLE(len(s.Beatmap.Notes[s.CurrentSentenceIndex].Sentence.GetRemainingRoma()))
+}
+
+// onTLE damages life gauge and records TLE on timeline
+func (s *GameState) onTLE(RemainingLength int) {
+	if s.Life != nil {
+		s.Life.OnTLE(RemainingLength)
+	}
+	s.sample(judge.TLE)
+}
+
+// recordSentence records how the current sentence was typed until EndTime
+func (s *GameState) recordSentence(Judge judge.Judge, EndTime float64) {
+	Note := s.Beatmap.Notes[s.CurrentSentenceIndex]
+	Duration := (EndTime - Note.Time) / s.Rate
+
+	KPS := 0.0
+	if Duration > 0 {
+		KPS = float64(len(s.typed)) / Duration
+	}
+	Reaction := 0.0
+	if len(s.typed) > 0 {
+		Reaction = (s.firstKeyTime - Note.Time) / s.Rate
+	}
+
+	s.Sentences = append(s.Sentences, judge.SentenceResult{
+		NoteIndex: s.CurrentSentenceIndex,
+		Lyric:     Note.Sentence.OriginalSentence,
+		Judge:     Judge,
+		MissCount: Note.Sentence.MissCount,
+		Typed:     string(s.typed),
+		StartTime: Note.Time,
+		EndTime:   EndTime,
+		Duration:  Duration,
+		KPS:       KPS,
+		Reaction:  Reaction,
+	})
+}
+
+// failSentence gives up the current sentence as if time is up
+func (s *GameState) failSentence() {
+	s.recordSentence(judge.TLE, s.CurrentTime)
+	s.timeOut()
+	s.Beatmap.Notes[s.CurrentSentenceIndex].Sentence.IsFinished = true
+	s.setInputDisabled(true)
+	s.addEffector(FOREGROUND, 120, tleTextEffect)
+}
+
+// ParseKeyInput handles key input event from sdl, and returns how it was judged
+func (s *GameState) ParseKeyInput(renderer *sdl.Renderer, code sdl.Keycode, PrintLyric bool) judge.Judge {
+	if !((code >= 'a' && code <= 'z') || (code >= '0' && code <= '9') || code == '[' || code == ']' || code == ',' || code == '.' || code == ' ' || code == '-') {
+		return judge.IGNORED
+	}
 
 	if s.IsInputDisabled {
-		sehelper.Play(sehelper.UnneccesarySE)
-		return
+		s.playSE(sehelper.UnneccesarySE)
+		return judge.UNNECESSARY
 	}
 
 	KeyChar := string(code)
 	CurrentSentence := s.Beatmap.Notes[s.CurrentSentenceIndex].Sentence
+	Expected := CurrentSentence.GetRemainingRoma()
 	ok, SentenceEnded := CurrentSentence.JudgeKeyInput(KeyChar)
 
+	if !ok && Expected != "" {
+		s.Mistakes.Record(Expected[0], byte(code))
+	}
+
 	Point := s.AddPoint(ok, SentenceEnded)
 
 	if !ok {
-		AddEffector(FOREGROUND, 120, missTypeTextEffect)
-		AddEffector(BACKGROUND, 15, missTypeBackgroundEffect)
-		sehelper.Play(sehelper.FailedSE)
-		return
+		s.addEffector(FOREGROUND, 120, missTypeTextEffect)
+		s.addEffector(BACKGROUND, 15, missTypeBackgroundEffect)
+		s.playSE(sehelper.FailedSE)
+		if s.Mods.Has(mods.PerfectOnly) {
+			s.failSentence()
+		}
+		return judge.MISS
 	}
 
 	s.CountKeyType()
-	AddEffector(FOREGROUND, 30, successEffect)
+	if len(s.typed) == 0 {
+		s.firstKeyTime = s.CurrentTime
+	}
+	s.typed = append(s.typed, byte(code))
+	s.Rhythm.Record(s.Beatmap.Notes[s.CurrentSentenceIndex].Time, s.CurrentTime)
+	s.addEffector(FOREGROUND, 30, successEffect)
 
-	if !PrintLyric {
+	if !PrintLyric && !s.Silent {
 		KeyPos := keyboard.GetKeyPos(KeyChar)
 		text := fmt.Sprintf("+%d", Point)
 		textwidth := helper.GetTextSize(renderer, helper.FullFont, text, Constants.BlueThickColor).W()
 		KeyPos = pos.FromXY(KeyPos.X()-textwidth/2, KeyPos.Y())
 
-		AddEffector(FOREGROUND, 30, effects.NewAbsoluteFadeout(
+		s.addEffector(FOREGROUND, 30, effects.NewAbsoluteFadeout(
 			text,
 			Constants.BlueThickColor,
 			helper.FullFont,
@@ -250,22 +403,26 @@ func (s *GameState) ParseKeyInput(renderer *sdl.Renderer, code sdl.Keycode, Prin
 	}
 
 	if SentenceEnded {
-		s.IsInputDisabled = true
+		s.setInputDisabled(true)
 
 		if CurrentSentence.MissCount == 0 {
-			AddEffector(FOREGROUND, 120, acTextEffect)
-			AddEffector(BACKGROUND, 15, acBackgroundEffect)
-			sehelper.Play(sehelper.AcSE)
-		} else {
-			AddEffector(FOREGROUND, 120, waTextEffect)
-			AddEffector(BACKGROUND, 15, waBackgroundEffect)
-			sehelper.Play(sehelper.WaSE)
+			s.recordSentence(judge.AC, s.CurrentTime)
+			s.addEffector(FOREGROUND, 120, acTextEffect)
+			s.addEffector(BACKGROUND, 15, acBackgroundEffect)
+			s.playSE(sehelper.AcSE)
+			return judge.AC
 		}
+		s.recordSentence(judge.WA, s.CurrentTime)
+		s.addEffector(FOREGROUND, 120, waTextEffect)
+		s.addEffector(BACKGROUND, 15, waBackgroundEffect)
+		s.playSE(sehelper.WaSE)
+		return judge.WA
+	}
+
+	if s.Speed.Window(s.CurrentTime)*s.Rate > 4 {
+		s.playSE(sehelper.FastSE)
 	} else {
-		if s.GetKeyTypePerSecond() > 4 {
-			sehelper.Play(sehelper.FastSE)
-		} else {
-			sehelper.Play(sehelper.SuccessSE)
-		}
+		s.playSE(sehelper.SuccessSE)
 	}
+	return judge.CORRECT
 }
@@ -1,60 +1,178 @@
 package mainview
 
 import (
+	"fmt"
+	"musicaltyper-go/game/audio"
+	"musicaltyper-go/game/autoplay"
 	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/clock"
+	"musicaltyper-go/game/config"
 	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/mods"
+	"musicaltyper-go/game/replay"
+	"musicaltyper-go/game/sehelper"
 	"musicaltyper-go/game/view"
 	"musicaltyper-go/game/view/game/component"
 	Body "musicaltyper-go/game/view/game/component/body"
 	Keyboard "musicaltyper-go/game/view/game/component/keyboard"
+	Overlay "musicaltyper-go/game/view/game/component/overlay"
 	RealTimeInfo "musicaltyper-go/game/view/game/component/realtimeinfo"
 	Top "musicaltyper-go/game/view/game/component/top"
 	"musicaltyper-go/game/view/result"
 	"time"
 
-	"github.com/veandco/go-sdl2/mix"
-
 	"github.com/veandco/go-sdl2/sdl"
 )
 
 type gameView struct {
 	frameCount         int
 	printingNextLyrics bool
-	musicStartTime     *time.Time
+	clock              clock.Clock
 	state              *GameState
-	music              *mix.Music
+	music              *audio.Player
+	replay             *replay.Replay
+	ghost              *ghost
+	practice           *practiceState
+	autoplay           *autoplay.Typist
+	demo               bool
+
+	// restart makes view to play the same chart again from the beginning
+	restart  func() view.View
+	nextView view.View
+	result   *result.GameResult
+	// closing means the view asked to go back to the previous view
+	closing bool
+
+	pauseState         PauseState
+	pauseMenuIndex     int
+	countdownStartTime time.Time
+
+	// skippingOutro means music is fading out to show result
+	skippingOutro bool
+}
+
+// PlayOptions has settings chosen before play
+type PlayOptions struct {
+	// Ghost is replay to race against, or nil
+	Ghost *replay.Replay
+	// Practice is settings of practice mode, or nil to play normally
+	Practice *Practice
+	// Rate is playback rate of whole song, one of PlayRates. 0 means normal speed.
+	Rate float64
+	Mods mods.Mod
+	// Autoplay is settings of autoplay, or nil to be played by the player
+	Autoplay *Autoplay
 }
 
-func NewMainView(beatmap *beatmap.Beatmap) view.View {
-	Music, _ := mix.LoadMUS(beatmap.Properties["song_data"])
-	Music.Play(1)
-	MusicStartTime := time.Now()
+var (
+	// PlayRates are playback rates which can be chosen for whole song
+	PlayRates = []float64{0.75, 1, 1.25, 1.5}
+)
+
+// NewMainView makes view to play the beatmap
+func NewMainView(beatmap *beatmap.Beatmap, options PlayOptions) view.View {
+	Music := loadMusic(beatmap)
+	result := newGameView(beatmap, Music)
+	result.state.Mods = options.Mods
+	Original := beatmap.Clone()
+	result.restart = func() view.View {
+		return NewMainView(Original.Clone(), options)
+	}
 
-	result := gameView{
+	if options.Autoplay != nil {
+		result.startAutoplay(*options.Autoplay)
+	}
+
+	if options.Practice != nil {
+		//Practice loops and jumps, so it can't be replayed or raced.
+		result.startPractice(*options.Practice)
+	} else {
+		if options.Autoplay == nil {
+			result.replay = replay.NewReplay(beatmap)
+		}
+		if options.Rate > 0 {
+			Music.SetRate(options.Rate)
+			result.state.SetRate(options.Rate)
+		}
+		if result.replay != nil {
+			result.replay.Rate = result.state.Rate
+			result.replay.Mods = options.Mods
+		}
+		if options.Ghost != nil {
+			result.ghost = newGhost(beatmap, options.Ghost)
+		}
+	}
+
+	return result
+}
+
+func newGameView(beatmap *beatmap.Beatmap, music *audio.Player) *gameView {
+	ClearEffectors()
+
+	return &gameView{
 		frameCount:         0,
 		printingNextLyrics: false,
-		musicStartTime:     &MusicStartTime,
+		clock:              music,
 		state:              NewGameState(beatmap),
-		music:              Music,
+		music:              music,
 	}
-	return &result
+}
+
+// loadMusic decodes song of the beatmap
+func loadMusic(Map *beatmap.Beatmap) *audio.Player {
+	Logger := logger.NewLogger("LoadMusic")
+
+	Music, Err := audio.Load(Map.Properties["song_data"])
+	Logger.CheckError(Err)
+	Music.SetVolume(constants.MusicVolume)
+	Music.SetPreservePitch(true)
+	return Music
 }
 
 func (v *gameView) GetName() string {
 	return "GameView"
 }
 
-var GameResult *result.GameResult = nil
+// OnEnter starts playing the song
+func (v *gameView) OnEnter() {
+	if v.music != nil && v.pauseState == PLAYING {
+		v.music.Play()
+	}
+}
 
-func (v *gameView) PollEvent() view.Event {
-	if GameResult != nil {
-		mix.HaltMusic()
+// OnExit stops counting typing speed, and frees the song unless result view has taken it
+func (v *gameView) OnExit() {
+	v.state.SetPaused(true)
+	if v.music != nil {
 		v.music.Free()
-		v.state.stopTypeSpeedCalcDaemon <- true
+		v.music = nil
+	}
+}
+
+func (v *gameView) PollEvent() view.Event {
+	if ev := v.pollNextView(); ev != nil {
+		return ev
+	}
+
+	if v.result != nil {
+		v.music.Pause()
+		v.saveHistory()
+		v.saveReplay()
+
+		if v.demo {
+			ev := view.ChangeViewEvent{
+				ToChangeView: v.restart(),
+			}
+			return &ev
+		}
 
 		ev := view.ChangeViewEvent{
-			ToChangeView: result.NewResultView(GameResult),
+			ToChangeView: result.NewResultView(v.result, v.restart, v.music),
 		}
+		v.music = nil
 		return &ev
 	}
 	return nil
@@ -62,19 +180,54 @@ func (v *gameView) PollEvent() view.Event {
 
 func (v *gameView) HandleSDLEvent(renderer *sdl.Renderer, event sdl.Event) bool {
 	switch e := event.(type) {
+	case *sdl.WindowEvent:
+		if e.Event == sdl.WINDOWEVENT_FOCUS_LOST && v.pauseState != PAUSED {
+			v.pause()
+		}
+
 	case *sdl.KeyboardEvent:
 		key := e.Keysym.Sym
 		if e.Type == sdl.KEYDOWN {
+			if v.demo {
+				//Players only watch demo. Any key goes back to the previous view.
+				v.closing = true
+				return true
+			}
+
+			switch v.pauseState {
+			case PAUSED:
+				v.handlePauseMenu(key)
+				return true
+
+			case COUNTDOWN:
+				if key == sdl.K_ESCAPE {
+					v.pause()
+				}
+				return true
+			}
+
 			switch key {
 			case sdl.K_ESCAPE:
-				return false
+				v.pause()
+				return true
 
 			case sdl.K_LSHIFT, sdl.K_RSHIFT:
 				v.printingNextLyrics = !v.printingNextLyrics
 				return true
 
+			case sdl.K_RETURN:
+				v.handleSkipKey()
+				return true
+
 			default:
-				v.state.ParseKeyInput(renderer, key, v.printingNextLyrics)
+				if v.practice != nil && v.handlePracticeKey(key) {
+					return true
+				}
+				if v.autoplay != nil {
+					//Players only watch autoplay.
+					return true
+				}
+				v.typeKey(renderer, key, v.clock.Now())
 			}
 		}
 	}
@@ -82,22 +235,154 @@ func (v *gameView) HandleSDLEvent(renderer *sdl.Renderer, event sdl.Event) bool
 	return true
 }
 
+// typeKey passes key typed at the time to game, and records it
+func (v *gameView) typeKey(renderer *sdl.Renderer, key sdl.Keycode, Time float64) {
+	v.state.Update(Time)
+	Judge := v.state.ParseKeyInput(renderer, key, v.isKeyboardHidden())
+	if Judge != judge.IGNORED && v.replay != nil {
+		v.replay.Record(v.state.CurrentTime, byte(key), Judge)
+	}
+}
+
+// isKeyboardHidden returns whether on-screen keyboard isn't shown
+func (v *gameView) isKeyboardHidden() bool {
+	return v.printingNextLyrics || v.state.Mods.Has(mods.NoKeyboard)
+}
+
+// makeResult makes GameResult from current state
+func (v *gameView) makeResult() *result.GameResult {
+	return &result.GameResult{
+		Rank:            v.state.GetRank(),
+		Point:           v.state.GetScore(),
+		TypeSpeed:       v.state.GetKeyTypePerSecond(),
+		Accuracy:        v.state.GetAccuracy(),
+		AchievementRate: v.state.GetAchievementRate(false),
+		MapInfo:         v.state.Beatmap.Properties,
+		RuleID:          v.state.Rule.ID(),
+		Practice:        v.practice != nil,
+		Rate:            v.state.Rate,
+		Mods:            v.state.Mods,
+		Autoplay:        v.autoplay != nil,
+		Mistakes:        v.state.Mistakes,
+		Sentences:       v.state.Sentences,
+		Rhythm:          v.state.GetRhythmStats(),
+		Timeline:        v.state.Timeline,
+		Sections:        v.state.Beatmap.Sections,
+		Length:          v.state.Beatmap.Notes[len(v.state.Beatmap.Notes)-1].Time,
+		ChartID:         v.state.Beatmap.ChartID,
+		MapHash:         v.state.Beatmap.Fingerprint,
+		MapVersion:      v.state.Beatmap.Version(),
+		Chart:           v.state.Beatmap.Path,
+		PlayedAt:        time.Now(),
+	}
+}
+
+// saveHistory adds finished play to history, and marks the result if it's new personal best.
+// Practice, autoplay and replay playback are not saved.
+func (v *gameView) saveHistory() {
+	if v.replay == nil || v.practice != nil || v.autoplay != nil {
+		return
+	}
+	Logger := logger.NewLogger("SaveHistory")
+
+	Store, Err := history.Open(config.Get().HistoryPath)
+	if Err != nil {
+		Logger.Warn("Failed to open history: " + Err.Error())
+		return
+	}
+
+	Map := v.state.Beatmap
+	ReplayPath := v.saveReplay()
+	Record := history.Record{
+		MapHash:         v.result.MapHash,
+		ChartID:         v.result.ChartID,
+		MapVersion:      v.result.MapVersion,
+		MapTitle:        Map.Properties["title"],
+		Chart:           v.result.Chart,
+		RuleID:          v.result.RuleID,
+		Mods:            v.result.Mods,
+		Rate:            v.result.Rate,
+		Point:           v.result.Point,
+		Accuracy:        v.result.Accuracy,
+		TypeSpeed:       v.result.TypeSpeed,
+		AchievementRate: v.result.AchievementRate,
+		Rank:            v.result.Rank.Text(),
+		Failed:          v.result.Failed,
+		Mistakes:        v.result.Mistakes.ToMap(),
+		Replay:          ReplayPath,
+		PlayedAt:        v.result.PlayedAt,
+	}
+
+	if Best, Found := Store.PersonalBest(Record.MapHash, Record.RuleID, Record.Rate, Record.Mods); Found {
+		v.result.PreviousBest = &Best
+		v.result.IsNewBest = !Record.Failed && Record.IsBetterThan(Best)
+	} else {
+		v.result.IsNewBest = !Record.Failed
+	}
+
+	v.result.OlderVersionCount = len(Store.OlderVersions(Map.ChartID, Map.Fingerprint, Map.Path))
+
+	if Err := Store.Add(Record); Err != nil {
+		Logger.Warn("Failed to save history: " + Err.Error())
+	}
+}
+
+// saveReplay writes replay of this play only once, and returns its path. Returns empty if not saved.
+func (v *gameView) saveReplay() string {
+	if v.replay == nil {
+		return ""
+	}
+	Logger := logger.NewLogger("SaveReplay")
+
+	Path, Err := v.replay.Save(constants.ReplayDirectory)
+	v.replay = nil
+	if Err != nil {
+		Logger.Warn("Failed to save replay: " + Err.Error())
+		return ""
+	}
+	fmt.Println("Saved replay to", Path)
+	return Path
+}
+
 func (v *gameView) Draw(Renderer *sdl.Renderer) {
+	if v.autoplay != nil && v.pauseState == PLAYING {
+		v.feedAutoplay(Renderer)
+	}
+	if v.render(Renderer) {
+		v.drawPause(Renderer)
+		Renderer.Present()
+	}
+}
+
+// render draws game screen without presenting, and returns false if the game has ended
+func (v *gameView) render(Renderer *sdl.Renderer) bool {
 	Beatmap := v.state.Beatmap
 
-	v.frameCount = (v.frameCount + 1) % constants.FrameRate
-	v.state.Update(float64(time.Now().Sub(*v.musicStartTime).Milliseconds()) / 1000.0)
-
-	if Beatmap.Notes[v.state.CurrentSentenceIndex].Type == beatmap.END {
-		GameResult = &result.GameResult{
-			Rank:            v.state.GetRank(),
-			Point:           v.state.Point,
-			TypeSpeed:       v.state.GetKeyTypePerSecond(),
-			Accuracy:        v.state.GetAccuracy(),
-			AchievementRate: v.state.GetAchievementRate(false),
-			MapInfo:         v.state.Beatmap.Properties,
-		}
-		return
+	v.updatePause()
+	IsPlaying := v.pauseState == PLAYING
+	if IsPlaying {
+		v.frameCount = (v.frameCount + 1) % constants.FrameRate
+	}
+	v.state.Update(v.clock.Now())
+	if v.practice != nil {
+		v.updatePractice()
+	}
+	if v.ghost != nil {
+		v.ghost.update(v.state.CurrentTime)
+		v.ghost.checkSplit(v.state)
+	}
+
+	if v.state.IsFailed() {
+		v.music.Pause()
+		sehelper.Play(sehelper.GameoverSE)
+		v.result = v.makeResult()
+		v.result.Failed = true
+		return false
+	}
+
+	if Beatmap.Notes[v.state.CurrentSentenceIndex].Type == beatmap.END || v.isOutroSkipped() {
+		v.result = v.makeResult()
+		return false
 	}
 
 	var (
@@ -108,16 +393,32 @@ func (v *gameView) Draw(Renderer *sdl.Renderer) {
 		NextLyrics                      = v.state.Beatmap.Notes[CurrentSentenceIndex+1 : CurrentSentenceIndex+4]
 		FrameCount                      = v.frameCount
 		Combo                           = v.state.Combo
-		Point                           = v.state.Point
-		IsKeyboardDisabled              = v.printingNextLyrics
+		Point                           = v.state.GetScore()
+		IsKeyboardDisabled              = v.isKeyboardHidden()
+		Mods                            = v.state.Mods
 		IsInputDisabled                 = v.state.IsInputDisabled
 		Rank                            = v.state.GetRank()
 		Accuracy                        = v.state.GetAccuracy()
-		TypingSpeed                     = v.state.GetKeyTypePerSecond()
+		TypingSpeed                     = v.state.GetSpeedStats()
+		Rhythm                          = v.state.GetRhythmStats()
 		AchievementRate                 = v.state.GetAchievementRate(false)
 		DrawBeginTime                   = time.Now()
+		GhostPointDiff                  = 0
+		Life                            = v.state.Life
+		LifeValue               float64 = 0
+		GhostCursorDiff                 = 0
+		GhostSentenceDiff               = 0
 	)
 
+	if Life != nil {
+		LifeValue = Life.Value()
+	}
+
+	if v.ghost != nil {
+		GhostPointDiff = v.ghost.pointDiff(v.state)
+		GhostSentenceDiff, GhostCursorDiff = v.ghost.cursorDiff(v.state)
+	}
+
 	if len(v.state.Beatmap.Notes) <= v.state.CurrentSentenceIndex+1 {
 		NormalizedRemainingTime = 1
 	} else {
@@ -135,26 +436,34 @@ func (v *gameView) Draw(Renderer *sdl.Renderer) {
 	backgroundComponents := []component.Drawable{
 		Top.SongInfo(Properties),
 		Top.Score(Point, FrameCount),
+		Top.GhostDiff(v.ghost == nil, GhostPointDiff),
 		Body.TimeGauge(NormalizedRemainingTime),
+		Body.LifeGauge(Life == nil, LifeValue, FrameCount),
 	}
-	backgroundEffectors = drawComponents(Renderer, backgroundComponents, backgroundEffectors)
+	backgroundEffectors = drawComponents(Renderer, backgroundComponents, backgroundEffectors, IsPlaying)
 
 	foregroundComponents := []component.Drawable{
-		Body.TypeText(CurrentSentence),
+		Body.TypeText(CurrentSentence, Mods.Has(mods.HiddenHiragana), Mods.Has(mods.HiddenRoma)),
+		Body.ModsText(Mods.String()),
+		Body.GhostCursor(v.ghost == nil || IsInputDisabled, GhostSentenceDiff, GhostCursorDiff),
 		Body.ComboText(Combo),
-		Body.AccGauge(CurrentSentence, AchievementRate, Rank),
+		Body.AccGauge(CurrentSentence, AchievementRate, Rank, v.state.Projection),
 		Body.AchievementGauge(AchievementRate),
-		Keyboard.Keyboard(IsKeyboardDisabled, IsInputDisabled, CurrentSentence),
+		Keyboard.Keyboard(IsKeyboardDisabled, IsInputDisabled, Mods.Has(mods.NoHighlight), CurrentSentence),
 		Keyboard.NextLyrics(!IsKeyboardDisabled, NextLyrics),
 		RealTimeInfo.SpeedGauge(TypingSpeed, FrameCount),
 		RealTimeInfo.CorrectRateText(Accuracy),
 		RealTimeInfo.AchievementRate(AchievementRate),
+		RealTimeInfo.RhythmText(Rhythm),
 	}
-	foregroundEffectors = drawComponents(Renderer, foregroundComponents, foregroundEffectors)
+	foregroundEffectors = drawComponents(Renderer, foregroundComponents, foregroundEffectors, IsPlaying)
 
+	if v.practice != nil {
+		Top.PlaybackStatus(v.practice.status())(Renderer)
+	}
+	Overlay.SkipHint(v.skipHint())(Renderer)
 	Top.Drawtime(&DrawBeginTime, FrameCount, len(foregroundEffectors), len(backgroundEffectors))(Renderer)
-
-	Renderer.Present()
+	return true
 }
 
 // EffectorPos is kind of effector's position
@@ -178,6 +487,12 @@ var (
 	backgroundEffectors = make([]*effectorEntry, 0)
 )
 
+// ClearEffectors removes all effectors
+func ClearEffectors() {
+	foregroundEffectors = make([]*effectorEntry, 0)
+	backgroundEffectors = make([]*effectorEntry, 0)
+}
+
 // AddEffector adds effector with position and duration
 func AddEffector(Pos EffectorPos, Duration int, Effector component.DrawableEffect) {
 	NewEntry := new(effectorEntry)
@@ -194,8 +509,8 @@ func AddEffector(Pos EffectorPos, Duration int, Effector component.DrawableEffec
 	}
 }
 
-//コンポーネントとエフェクトを描画して残ったエフェクトを返す
-func drawComponents(renderer *sdl.Renderer, components []component.Drawable, effectors []*effectorEntry) []*effectorEntry {
+//コンポーネントとエフェクトを描画して残ったエフェクトを返す。advanceがfalseならエフェクトを進めない
+func drawComponents(renderer *sdl.Renderer, components []component.Drawable, effectors []*effectorEntry, advance bool) []*effectorEntry {
 	for _, v := range components {
 		v(renderer)
 	}
@@ -205,7 +520,9 @@ func drawComponents(renderer *sdl.Renderer, components []component.Drawable, eff
 
 	RemainEffectors := make([]*effectorEntry, 0, len(effectors))
 	for _, v := range effectors {
-		v.FrameCount++
+		if advance || v.FrameCount < 0 {
+			v.FrameCount++
+		}
 		EffectorContext.FrameCount = v.FrameCount
 		EffectorContext.Duration = v.Duration
 
@@ -0,0 +1,54 @@
+package result
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/export"
+	"musicaltyper-go/game/rank"
+	"musicaltyper-go/game/view/result/component"
+	"musicaltyper-go/game/view/result/component/center"
+	"musicaltyper-go/game/view/result/component/top"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// DrawCard returns function to draw result image card of the play, which looks like summary of result screen
+func DrawCard(Play export.Play) func(Renderer *sdl.Renderer) {
+	return func(Renderer *sdl.Renderer) {
+		constants.BackgroundColor.ApplyColor(Renderer)
+		Renderer.Clear()
+
+		//Rank is decided again to get its color, because the play may come from history which has only its text.
+		Rank := rank.ForRule(Play.RuleID).FromAchievementRate(Play.AchievementRate)
+		RankText := center.RankText(Rank)
+		if Play.Failed {
+			RankText = center.FailedText()
+		}
+
+		MapInfo := map[string]string{"title": Play.Title}
+		if Play.Artist != "" {
+			MapInfo["song_author"] = Play.Artist
+		}
+
+		Components := []component.Drawable{
+			top.SongInfo(MapInfo),
+			top.PlayMode(playModeText(Play.Autoplay, Play.Practice, Play.Rate, Play.Mods)),
+			RankText,
+			center.ScoreText(Play.Point, Play.Accuracy, Play.AchievementRate, Rank),
+			center.SpeedGauge(Play.TypeSpeed),
+			cardFooter(Play),
+		}
+		for _, v := range Components {
+			v(Renderer)
+		}
+	}
+}
+
+// cardFooter draws when the play was played
+func cardFooter(Play export.Play) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 326), helper.LeftAlign, helper.SystemFont, Play.PlayedAt.Format("2006-01-02 15:04"), constants.TextColor.Brighter(50))
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, 326), helper.RightAlign, helper.SystemFont, constants.WindowTitle, constants.TextColor.Brighter(50))
+	}
+}
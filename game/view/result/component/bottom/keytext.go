@@ -1,6 +1,7 @@
 package bottom
 
 import (
+	"fmt"
 	"musicaltyper-go/game/constants"
 	"musicaltyper-go/game/draw/helper"
 	"musicaltyper-go/game/draw/pos"
@@ -12,6 +13,24 @@ import (
 func KeyText() component.Drawable {
 	return func(Renderer *sdl.Renderer) {
 		helper.DrawText(Renderer, pos.FromXY(constants.Margin-10, 320), helper.LeftAlign, helper.AlphabetFont, "[R]/リトライ", constants.TextColor)
-		helper.DrawText(Renderer, pos.FromXY(constants.Margin+300, 320), helper.LeftAlign, helper.AlphabetFont, "[Esc]/終了", constants.TextColor)
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin+300, 320), helper.LeftAlign, helper.AlphabetFont, "[Esc]/戻る", constants.TextColor)
+	}
+}
+
+// HelpText draws message of the last operation, or keys available on every page if Message is empty
+func HelpText(Message string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Message == "" {
+			Message = "[R]/リトライ [Esc]/戻る [←→]/ページ [E]/保存"
+		}
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, constants.WindowHeight-30), helper.LeftAlign, helper.SystemFont, Message, constants.TextColor)
+	}
+}
+
+// PageText draws which page is shown
+func PageText(Current, Count int, Title string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Text := fmt.Sprintf("%s %d/%d", Title, Current+1, Count)
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, constants.WindowHeight-30), helper.RightAlign, helper.SystemFont, Text, constants.TextColor)
 	}
 }
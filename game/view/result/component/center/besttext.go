@@ -0,0 +1,47 @@
+package center
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/view/result/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// BestText draws new personal best indication, or previous personal best to compare
+func BestText(AchievementRate float64, IsNewBest bool, PreviousBest *history.Record) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		var (
+			Text  string
+			Color = constants.TextColor
+		)
+
+		switch {
+		case IsNewBest && PreviousBest != nil:
+			Text = fmt.Sprintf("NEW RECORD! (%+.2f%%)", (AchievementRate-PreviousBest.AchievementRate)*100)
+			Color = constants.GreenThickColor
+		case IsNewBest:
+			Text = "NEW RECORD!"
+			Color = constants.GreenThickColor
+		case PreviousBest != nil:
+			Text = fmt.Sprintf("自己ベスト %.2f%%", PreviousBest.AchievementRate*100)
+		default:
+			return
+		}
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-15, 110), helper.RightAlign, helper.SystemFont, Text, Color)
+	}
+}
+
+// OlderVersionText tells plays on older versions of the chart are excluded from comparison
+func OlderVersionText(Count int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Count == 0 {
+			return
+		}
+		Text := fmt.Sprintf("旧バージョンの記録 %d件は比較対象外", Count)
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-15, 128), helper.RightAlign, helper.SystemFont, Text, constants.TextColor.Brighter(50))
+	}
+}
@@ -12,8 +12,13 @@ import (
 
 func RankText(r rank.Rank) component.Drawable {
 	return func(Renderer *sdl.Renderer) {
-		Color := constants.RedColor.Darker(int(150.0 * (r.BorderRate() / 200)))
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 75), helper.LeftAlign, helper.BigFont, r.Text(), r.Color())
+	}
+}
 
-		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 75), helper.LeftAlign, helper.BigFont, r.Text(), Color)
+// FailedText draws failed indication instead of rank
+func FailedText() component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 75), helper.LeftAlign, helper.BigFont, "Failed", constants.RedColor.Darker(100))
 	}
 }
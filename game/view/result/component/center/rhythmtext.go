@@ -0,0 +1,26 @@
+package center
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/speed"
+	"musicaltyper-go/game/view/result/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// RhythmText draws average reaction, spread of key intervals, and speed excluding reaction
+func RhythmText(Rhythm speed.RhythmStats) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Texts := []string{
+			fmt.Sprintf("平均反応 %.2fs", Rhythm.Reaction),
+			fmt.Sprintf("ゆらぎ %.0fms", Rhythm.IntervalSpread*1000),
+			fmt.Sprintf("初速抜き %.2f key/s", Rhythm.TypingKPS),
+		}
+		for i, Text := range Texts {
+			helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-15, 245+i*22), helper.RightAlign, helper.SystemFont, Text, constants.TextColor.Brighter(50))
+		}
+	}
+}
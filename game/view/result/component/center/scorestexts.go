@@ -33,8 +33,8 @@ func ScoreText(Point int, Accuracy, AchievementRate float64, r rank.Rank) compon
 				NextRankText   = NextRank.Text() + "まで"
 				ToNextRankText = fmt.Sprintf("%06.2f%%", NextRank.BorderRate()-(AchievementRate*100))
 			)
-			helper.DrawText(Renderer, pos.FromXY(constants.Margin+200, 158), helper.LeftAlign, helper.SystemFont, NextRankText, constants.BlueThickColor)
-			helper.DrawText(Renderer, pos.FromXY(constants.Margin+200, 168), helper.LeftAlign, helper.AlphabetFont, ToNextRankText, constants.BlueThickColor)
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin+200, 158), helper.LeftAlign, helper.SystemFont, NextRankText, NextRank.Color())
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin+200, 168), helper.LeftAlign, helper.AlphabetFont, ToNextRankText, NextRank.Color())
 		}
 
 		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 150), helper.LeftAlign, helper.JapaneseFont, AchievementRateText, AchievementRateColor)
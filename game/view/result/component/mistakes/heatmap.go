@@ -0,0 +1,92 @@
+package mistakes
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/color"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/mistake"
+	"musicaltyper-go/game/view/game/component/keyboard"
+	"musicaltyper-go/game/view/result/component"
+	"strings"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	pairCount = 5
+)
+
+var (
+	coldColor = color.FromRGB(255, 255, 255)
+	hotColor  = constants.RedColor
+)
+
+// Heatmap draws how many times mistyped where each key is expected, over the virtual keyboard layout
+func Heatmap(Mistakes *mistake.Matrix) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Max := 0
+		for _, Row := range keyboard.KeyboardKeys {
+			for i := range Row {
+				if Count := Mistakes.MissesOf(Row[i]); Count > Max {
+					Max = Count
+				}
+			}
+		}
+
+		for _, Row := range keyboard.KeyboardKeys {
+			for _, Key := range strings.Split(Row, "") {
+				Area, ok := keyboard.KeyArea(Key)
+				if !ok {
+					continue
+				}
+
+				Heat := 0.0
+				if Max > 0 {
+					Heat = float64(Mistakes.MissesOf(Key[0])) / float64(Max)
+				}
+				helper.DrawFillRect(Renderer, coldColor.Mix(hotColor, Heat), Area)
+				helper.DrawLineRect(Renderer, constants.TextColor, Area, 2)
+
+				Label := strings.ToUpper(Key)
+				TextSize := helper.GetTextSize(Renderer, helper.FullFont, Label, constants.TextColor)
+				helper.DrawText(Renderer,
+					pos.FromXY(Area.X()+Area.W()/2-TextSize.W()/2, Area.Y()+Area.H()/2-TextSize.H()/2),
+					helper.LeftAlign, helper.FullFont, Label, constants.TextColor)
+			}
+		}
+
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 100), helper.LeftAlign, helper.FullFont,
+			fmt.Sprintf("ミス %d回", Mistakes.Total()), constants.TextColor)
+	}
+}
+
+// ConfusedPairs draws pairs of expected and typed keys which were mistaken most
+func ConfusedPairs(Mistakes *mistake.Matrix) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, 380), helper.LeftAlign, helper.SystemFont,
+			"よく間違える組み合わせ (正 → 誤)", constants.TextColor)
+
+		Pairs := Mistakes.MostConfused(pairCount)
+		if len(Pairs) == 0 {
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin+10, 400), helper.LeftAlign, helper.SystemFont,
+				"なし", constants.TextColor.Brighter(50))
+			return
+		}
+
+		for i, v := range Pairs {
+			Text := fmt.Sprintf("%s → %s  ×%d", keyLabel(v.Expected), keyLabel(v.Typed), v.Count)
+			X := constants.Margin + 10 + (i%3)*200
+			Y := 400 + (i/3)*20
+			helper.DrawText(Renderer, pos.FromXY(X, Y), helper.LeftAlign, helper.SystemFont, Text, hotColor.Darker(80))
+		}
+	}
+}
+
+func keyLabel(Key byte) string {
+	if Key == ' ' {
+		return "Space"
+	}
+	return strings.ToUpper(string(Key))
+}
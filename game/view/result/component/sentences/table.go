@@ -0,0 +1,125 @@
+package sentences
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/color"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/view/result/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	// VisibleRows is how many rows are shown at once
+	VisibleRows = 16
+
+	headerY   = 100
+	rowsY     = 122
+	rowHeight = 20
+
+	lyricLength = 11
+	typedLength = 18
+)
+
+// columnX is left of each column: #, lyric, judge, misses, time, KPS, typed
+var columnX = [...]int{constants.Margin, 45, 235, 285, 330, 395, 450}
+
+// RowAt returns index of the row at Y, counted from the first visible row
+func RowAt(Y int) (int, bool) {
+	if Y < rowsY || Y >= rowsY+VisibleRows*rowHeight {
+		return 0, false
+	}
+	return (Y - rowsY) / rowHeight, true
+}
+
+// Table draws how each sentence was typed. Rows from Scroll are shown, and Selected row is highlighted.
+func Table(Sentences []judge.SentenceResult, Scroll, Selected int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Headers := [...]string{"#", "歌詞", "判定", "ミス", "時間", "KPS", "入力"}
+		for i, Header := range Headers {
+			helper.DrawText(Renderer, pos.FromXY(columnX[i], headerY), helper.LeftAlign, helper.SystemFont, Header, constants.TextColor.Brighter(50))
+		}
+
+		if len(Sentences) == 0 {
+			helper.DrawText(Renderer, pos.FromXY(columnX[1], rowsY), helper.LeftAlign, helper.SystemFont, "なし", constants.TextColor.Brighter(50))
+			return
+		}
+
+		for i := 0; i < VisibleRows && Scroll+i < len(Sentences); i++ {
+			Index := Scroll + i
+			Sentence := Sentences[Index]
+			Y := rowsY + i*rowHeight
+
+			if Index == Selected {
+				helper.DrawFillRect(Renderer, constants.BlueThickColor.Brighter(150), area.FromXYWH(0, Y, constants.WindowWidth, rowHeight))
+			}
+
+			Texts := [...]string{
+				fmt.Sprint(Index + 1),
+				truncate(Sentence.Lyric, lyricLength),
+				Sentence.Judge.String(),
+				fmt.Sprint(Sentence.MissCount),
+				fmt.Sprintf("%.2fs", Sentence.Duration),
+				fmt.Sprintf("%.1f", Sentence.KPS),
+				truncate(Sentence.Typed, typedLength),
+			}
+			for j, Text := range Texts {
+				Color := constants.TextColor
+				if j == 2 {
+					Color = judgeColor(Sentence.Judge)
+				}
+				if Text != "" {
+					helper.DrawText(Renderer, pos.FromXY(columnX[j], Y), helper.LeftAlign, helper.SystemFont, Text, Color)
+				}
+			}
+		}
+
+		if len(Sentences) > VisibleRows {
+			Text := fmt.Sprintf("%d-%d / %d", Scroll+1, min(Scroll+VisibleRows, len(Sentences)), len(Sentences))
+			helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, headerY), helper.RightAlign, helper.SystemFont, Text, constants.TextColor.Brighter(50))
+		}
+	}
+}
+
+// Detail draws whole romaji typed in the selected sentence, and keys to operate the table
+func Detail(Sentences []judge.SentenceResult, Selected int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Y := rowsY + VisibleRows*rowHeight + 6
+		if Selected < len(Sentences) && Sentences[Selected].Typed != "" {
+			Sentence := Sentences[Selected]
+			Text := fmt.Sprintf("反応 %.2fs  %s", Sentence.Reaction, Sentence.Typed)
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin, Y), helper.LeftAlign, helper.SystemFont, Text, constants.TextColor)
+		}
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, Y+20), helper.RightAlign, helper.SystemFont, "[↑↓]/選択 [Enter]/再生", constants.TextColor.Brighter(50))
+	}
+}
+
+func judgeColor(Judge judge.Judge) color.Color {
+	switch Judge {
+	case judge.AC:
+		return constants.GreenThickColor
+	case judge.WA:
+		return constants.BlueThickColor
+	default:
+		return constants.RedColor.Darker(50)
+	}
+}
+
+// truncate cuts Text into Length runes
+func truncate(Text string, Length int) string {
+	if len([]rune(Text)) <= Length {
+		return Text
+	}
+	return helper.Substring(Text, 0, Length-1) + "…"
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,113 @@
+package timeline
+
+import (
+	"fmt"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/timeline"
+	"musicaltyper-go/game/view/result/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	missTickHeight = 6
+	tleTickHeight  = 14
+)
+
+var (
+	chartArea = area.FromXYWH(65, 130, constants.WindowWidth-130, 270)
+
+	rateColor    = constants.BlueThickColor
+	comboColor   = constants.GreenThickColor
+	dipColor     = constants.RedColor
+	sectionColor = constants.TextColor.Brighter(150)
+)
+
+// Chart draws achievement rate and combo across song time, with sections and where misses and TLE happened
+func Chart(Samples timeline.Timeline, Sections []*beatmap.Section, Length float64) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		MaxRate := 1.0
+		for _, v := range Samples {
+			if v.AchievementRate > MaxRate {
+				MaxRate = v.AchievementRate
+			}
+		}
+		MaxCombo := Samples.MaxCombo()
+		if MaxCombo < 1 {
+			MaxCombo = 1
+		}
+
+		RateChart := helper.Chart{Area: chartArea, MaxX: Length, MaxY: MaxRate}
+		ComboChart := helper.Chart{Area: chartArea, MaxX: Length, MaxY: float64(MaxCombo)}
+
+		for _, v := range Sections {
+			RateChart.DrawVerticalLine(Renderer, v.Time, sectionColor)
+			Top := RateChart.Pos(v.Time, MaxRate)
+			helper.DrawText(Renderer, pos.FromXY(Top.X()+2, Top.Y()-18), helper.LeftAlign, helper.SystemFont, v.ID, sectionColor.Darker(60))
+		}
+		RateChart.DrawHorizontalLine(Renderer, 1, sectionColor)
+		helper.DrawLineRect(Renderer, constants.TextColor.Brighter(100), chartArea, 1)
+
+		var (
+			Times  = make([]float64, len(Samples))
+			Rates  = make([]float64, len(Samples))
+			Combos = make([]float64, len(Samples))
+		)
+		for i, v := range Samples {
+			Times[i] = v.Time
+			Rates[i] = v.AchievementRate
+			Combos[i] = float64(v.Combo)
+		}
+		ComboChart.DrawSeries(Renderer, Times, Combos, comboColor, 1)
+		RateChart.DrawSeries(Renderer, Times, Rates, rateColor, 2)
+
+		Bottom := chartArea.Y() + chartArea.H()
+		for _, v := range Samples {
+			Height := 0
+			switch v.Judge {
+			case judge.MISS:
+				Height = missTickHeight
+			case judge.TLE:
+				Height = tleTickHeight
+			default:
+				continue
+			}
+			X := RateChart.Pos(v.Time, 0).X()
+			helper.DrawFillRect(Renderer, dipColor, area.FromXYWH(X, Bottom-Height, 1, Height))
+		}
+
+		drawLabels(Renderer, MaxRate, MaxCombo, Length)
+	}
+}
+
+// drawLabels draws scales of both axes and legend
+func drawLabels(Renderer *sdl.Renderer, MaxRate float64, MaxCombo int, Length float64) {
+	var (
+		Left   = chartArea.X()
+		Right  = chartArea.X() + chartArea.W()
+		Top    = chartArea.Y()
+		Bottom = chartArea.Y() + chartArea.H()
+	)
+
+	helper.DrawText(Renderer, pos.FromXY(Left-4, Top-8), helper.RightAlign, helper.SystemFont, fmt.Sprintf("%.0f%%", MaxRate*100), rateColor)
+	helper.DrawText(Renderer, pos.FromXY(Left-4, Bottom-8), helper.RightAlign, helper.SystemFont, "0%", rateColor)
+	helper.DrawText(Renderer, pos.FromXY(Right+4, Top-8), helper.LeftAlign, helper.SystemFont, fmt.Sprint(MaxCombo), comboColor)
+	helper.DrawText(Renderer, pos.FromXY(Right+4, Bottom-8), helper.LeftAlign, helper.SystemFont, "0", comboColor)
+
+	helper.DrawText(Renderer, pos.FromXY(Left, Bottom+4), helper.LeftAlign, helper.SystemFont, "0:00", constants.TextColor)
+	helper.DrawText(Renderer, pos.FromXY(Right, Bottom+4), helper.RightAlign, helper.SystemFont, formatTime(Length), constants.TextColor)
+
+	LegendY := Bottom + 30
+	helper.DrawText(Renderer, pos.FromXY(Left, LegendY), helper.LeftAlign, helper.SystemFont, "― 達成率", rateColor)
+	helper.DrawText(Renderer, pos.FromXY(Left+100, LegendY), helper.LeftAlign, helper.SystemFont, "― コンボ", comboColor)
+	helper.DrawText(Renderer, pos.FromXY(Left+200, LegendY), helper.LeftAlign, helper.SystemFont, "| ミス / TLE", dipColor)
+}
+
+func formatTime(Seconds float64) string {
+	return fmt.Sprintf("%d:%02d", int(Seconds)/60, int(Seconds)%60)
+}
@@ -0,0 +1,21 @@
+package top
+
+import (
+	Constants "musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/view/result/component"
+
+	"musicaltyper-go/game/draw/pos"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PlayMode draws how the chart was played, e.g. practice. Draws nothing if Mode is empty.
+func PlayMode(Mode string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Mode == "" {
+			return
+		}
+		helper.DrawText(Renderer, pos.FromXY(Constants.WindowWidth-Constants.Margin, 60), helper.RightAlign, helper.SystemFont, Mode, Constants.RedColor.Darker(50))
+	}
+}
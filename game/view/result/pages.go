@@ -0,0 +1,130 @@
+package result
+
+import (
+	"musicaltyper-go/game/view/result/component"
+	"musicaltyper-go/game/view/result/component/bottom"
+	"musicaltyper-go/game/view/result/component/center"
+	"musicaltyper-go/game/view/result/component/mistakes"
+	"musicaltyper-go/game/view/result/component/sentences"
+	"musicaltyper-go/game/view/result/component/timeline"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// page is a screen of result switched by arrow keys
+type page struct {
+	title string
+	// components makes components drawn below song info
+	components func(v *resultView) []component.Drawable
+	// handleEvent handles event only for the page, and returns whether it was consumed. nil if none.
+	handleEvent func(v *resultView, event sdl.Event) bool
+}
+
+var (
+	pages = []page{
+		{"スコア", summaryComponents, nil},
+		{"推移", timelineComponents, nil},
+		{"ミス", mistakeComponents, nil},
+		{"文ごと", sentenceComponents, handleSentenceEvent},
+	}
+)
+
+func summaryComponents(v *resultView) []component.Drawable {
+	RankText := center.RankText(v.result.Rank)
+	if v.result.Failed {
+		RankText = center.FailedText()
+	}
+
+	return []component.Drawable{
+		RankText,
+		center.BestText(v.result.AchievementRate, v.result.IsNewBest, v.result.PreviousBest),
+		center.OlderVersionText(v.result.OlderVersionCount),
+		center.ScoreText(v.result.Point, v.result.Accuracy, v.result.AchievementRate, v.result.Rank),
+		center.SpeedGauge(v.result.TypeSpeed),
+		center.RhythmText(v.result.Rhythm),
+		bottom.KeyText(),
+	}
+}
+
+func mistakeComponents(v *resultView) []component.Drawable {
+	return []component.Drawable{
+		mistakes.Heatmap(v.result.Mistakes),
+		mistakes.ConfusedPairs(v.result.Mistakes),
+	}
+}
+
+func timelineComponents(v *resultView) []component.Drawable {
+	return []component.Drawable{
+		timeline.Chart(v.result.Timeline, v.result.Sections, v.result.Length),
+	}
+}
+
+func sentenceComponents(v *resultView) []component.Drawable {
+	return []component.Drawable{
+		sentences.Table(v.result.Sentences, v.scroll, v.selected),
+		sentences.Detail(v.result.Sentences, v.selected),
+	}
+}
+
+// handleSentenceEvent selects a sentence by keys, wheel or click, and plays its snippet
+func handleSentenceEvent(v *resultView, event sdl.Event) bool {
+	switch e := event.(type) {
+	case *sdl.KeyboardEvent:
+		if e.Type != sdl.KEYDOWN {
+			return false
+		}
+		switch e.Keysym.Sym {
+		case sdl.K_UP:
+			v.selectSentence(v.selected - 1)
+		case sdl.K_DOWN:
+			v.selectSentence(v.selected + 1)
+		case sdl.K_PAGEUP:
+			v.selectSentence(v.selected - sentences.VisibleRows)
+		case sdl.K_PAGEDOWN:
+			v.selectSentence(v.selected + sentences.VisibleRows)
+		case sdl.K_RETURN, sdl.K_SPACE:
+			v.playSnippet(v.selected)
+		default:
+			return false
+		}
+		return true
+
+	case *sdl.MouseWheelEvent:
+		v.selectSentence(v.selected - int(e.Y))
+		return true
+
+	case *sdl.MouseButtonEvent:
+		if e.Type != sdl.MOUSEBUTTONDOWN || e.Button != sdl.BUTTON_LEFT {
+			return false
+		}
+		Row, ok := sentences.RowAt(int(e.Y))
+		if !ok || v.scroll+Row >= len(v.result.Sentences) {
+			return false
+		}
+		v.selectSentence(v.scroll + Row)
+		v.playSnippet(v.selected)
+		return true
+	}
+	return false
+}
+
+// selectSentence selects the sentence, and scrolls the table to show it
+func (view *resultView) selectSentence(Index int) {
+	if len(view.result.Sentences) == 0 {
+		return
+	}
+	if Index < 0 {
+		Index = 0
+	}
+	if Index >= len(view.result.Sentences) {
+		Index = len(view.result.Sentences) - 1
+	}
+	view.selected = Index
+
+	if view.selected < view.scroll {
+		view.scroll = view.selected
+	}
+	if view.selected >= view.scroll+sentences.VisibleRows {
+		view.scroll = view.selected - sentences.VisibleRows + 1
+	}
+}
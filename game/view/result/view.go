@@ -1,32 +1,160 @@
 package result
 
 import (
+	"fmt"
+	"musicaltyper-go/game/audio"
+	"musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/config"
+	"musicaltyper-go/game/export"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/judge"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/mistake"
+	"musicaltyper-go/game/mods"
 	"musicaltyper-go/game/rank"
+	"musicaltyper-go/game/speed"
+	"musicaltyper-go/game/timeline"
 	"musicaltyper-go/game/view"
 	"musicaltyper-go/game/view/result/component"
 	"musicaltyper-go/game/view/result/component/bottom"
-	"musicaltyper-go/game/view/result/component/center"
 	"musicaltyper-go/game/view/result/component/top"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
 type GameResult struct {
-	Rank            rank.Rank
-	Point           int
-	TypeSpeed       float64
+	Rank      rank.Rank
+	Point     int
+	TypeSpeed float64
+	// Rhythm is reaction and consistency of key intervals
+	Rhythm          speed.RhythmStats
 	Accuracy        float64
 	AchievementRate float64
 	MapInfo         map[string]string
+
+	// Failed means life gauge has run out before the end
+	Failed bool
+
+	// RuleID is ID of scoring rule. Results with different rules are not comparable.
+	RuleID string
+
+	// ChartID, MapHash and MapVersion are identity of the chart, and Chart is path of the beatmap file
+	ChartID    string
+	MapHash    string
+	MapVersion string
+	Chart      string
+	PlayedAt   time.Time
+
+	// Practice means played in practice mode. Practice results must not be mixed with normal scores.
+	Practice bool
+	// Rate is playback rate of the song. 1 means normal speed.
+	Rate float64
+	Mods mods.Mod
+	// Autoplay means played by autoplay. Like practice, it must not be mixed with normal scores.
+	Autoplay bool
+
+	// IsNewBest means the play has updated personal best
+	IsNewBest bool
+	// PreviousBest is personal best before the play, or nil if none
+	PreviousBest *history.Record
+	// OlderVersionCount is how many plays were recorded on older versions of the chart, which aren't compared
+	OlderVersionCount int
+
+	// Mistakes records which key was typed where another key was expected
+	Mistakes *mistake.Matrix
+	// Sentences records how each sentence was typed
+	Sentences []judge.SentenceResult
+
+	// Timeline records achievement rate and combo at each judgement
+	Timeline timeline.Timeline
+	Sections []*beatmap.Section
+	// Length is song time of the end of the chart
+	Length float64
+}
+
+// playMode returns text of how the chart was played
+func (r *GameResult) playMode() string {
+	return playModeText(r.Autoplay, r.Practice, r.Rate, r.Mods.String())
+}
+
+// playModeText returns text of how the chart was played. Mods is made by mods.Mod.String.
+func playModeText(Autoplay, Practice bool, Rate float64, Mods string) string {
+	Texts := make([]string, 0, 4)
+	if Autoplay {
+		Texts = append(Texts, "AUTO")
+	}
+	if Practice {
+		Texts = append(Texts, "PRACTICE")
+	}
+	if Practice || Rate != 1 {
+		Texts = append(Texts, fmt.Sprintf("x%.2f", Rate))
+	}
+	if Mods != "" {
+		Texts = append(Texts, Mods)
+	}
+	return strings.Join(Texts, " ")
+}
+
+// Export makes the result in the form to share and analyse
+func (r *GameResult) Export() export.Play {
+	return export.Play{
+		Title:           r.MapInfo["title"],
+		Artist:          r.MapInfo["song_author"],
+		Chart:           r.Chart,
+		ChartID:         r.ChartID,
+		MapHash:         r.MapHash,
+		MapVersion:      r.MapVersion,
+		RuleID:          r.RuleID,
+		Mods:            r.Mods.String(),
+		Rate:            r.Rate,
+		Practice:        r.Practice,
+		Autoplay:        r.Autoplay,
+		Point:           r.Point,
+		Accuracy:        r.Accuracy,
+		TypeSpeed:       r.TypeSpeed,
+		Reaction:        r.Rhythm.Reaction,
+		IntervalSpread:  r.Rhythm.IntervalSpread,
+		TypingKPS:       r.Rhythm.TypingKPS,
+		AchievementRate: r.AchievementRate,
+		Rank:            r.Rank.Text(),
+		Failed:          r.Failed,
+		Mistakes:        r.Mistakes.ToMap(),
+		PlayedAt:        r.PlayedAt,
+		Sentences:       export.FromSentences(r.Sentences),
+	}
 }
 
 type resultView struct {
-	result *GameResult
+	result   *GameResult
+	retry    func() view.View
+	nextView view.View
+	// closing means the view asked to go back to the previous view
+	closing bool
+	page    int
+
+	// music plays snippets of sentences, or nil if not available
+	music *audio.Player
+	// snippetEnd is song time to stop the snippet
+	snippetEnd float64
+
+	// selected is index of the sentence selected in the table, and scroll is the first one shown
+	selected int
+	scroll   int
+
+	// exportMessage tells where the result was exported, or why failed
+	exportMessage string
 }
 
-func NewResultView(result *GameResult) view.View {
+// NewResultView makes view to show result. retry makes view to play the same chart again.
+// music is the song of the chart to play snippets, and it's freed when leaving the view.
+func NewResultView(result *GameResult, retry func() view.View, music *audio.Player) view.View {
 	Result := resultView{}
 	Result.result = result
+	Result.retry = retry
+	Result.music = music
 	return &Result
 }
 
@@ -34,14 +162,37 @@ func (view *resultView) GetName() string {
 	return "ResultView"
 }
 
-func (view *resultView) HandleSDLEvent(_ *sdl.Renderer, event sdl.Event) bool {
+func (view *resultView) OnEnter() {}
+
+// OnExit frees the song for snippets
+func (view *resultView) OnExit() {
+	view.freeMusic()
+}
+
+func (view *resultView) HandleSDLEvent(Renderer *sdl.Renderer, event sdl.Event) bool {
+	if Page := pages[view.page]; Page.handleEvent != nil && Page.handleEvent(view, event) {
+		return true
+	}
+
 	switch e := event.(type) {
 	case *sdl.KeyboardEvent:
 		key := e.Keysym.Sym
 		if e.Type == sdl.KEYDOWN {
 			switch key {
 			case sdl.K_ESCAPE:
-				return false
+				view.closing = true
+
+			case sdl.K_r:
+				view.nextView = view.retry()
+
+			case sdl.K_e:
+				view.export(Renderer)
+
+			case sdl.K_TAB, sdl.K_RIGHT:
+				view.page = (view.page + 1) % len(pages)
+
+			case sdl.K_LEFT:
+				view.page = (view.page + len(pages) - 1) % len(pages)
 			}
 		}
 	}
@@ -49,21 +200,91 @@ func (view *resultView) HandleSDLEvent(_ *sdl.Renderer, event sdl.Event) bool {
 	return true
 }
 
-func (view *resultView) PollEvent() view.Event {
-	return nil
+func (v *resultView) PollEvent() view.Event {
+	if v.closing {
+		v.closing = false
+		return &view.PopViewEvent{}
+	}
+	if v.nextView == nil {
+		return nil
+	}
+	ev := view.ChangeViewEvent{
+		ToChangeView: v.nextView,
+	}
+	v.nextView = nil
+	return &ev
+}
+
+// playSnippet plays the song while the sentence was shown
+func (view *resultView) playSnippet(Index int) {
+	if view.music == nil || Index >= len(view.result.Sentences) {
+		return
+	}
+	Sentence := view.result.Sentences[Index]
+	view.snippetEnd = Sentence.EndTime
+	view.music.Seek(Sentence.StartTime)
+	view.music.Play()
+}
+
+// stopSnippetAtEnd stops the snippet when it has been played to the end of the sentence
+func (view *resultView) stopSnippetAtEnd() {
+	if view.music != nil && !view.music.IsPaused() && view.music.Now() >= view.snippetEnd {
+		view.music.Pause()
+	}
+}
+
+// export saves the result as JSON, CSV of sentences and image card into export directory
+func (view *resultView) export(Renderer *sdl.Renderer) {
+	Logger := logger.NewLogger("ExportResult")
+
+	var (
+		Play = view.result.Export()
+		Base = filepath.Join(config.Get().ExportDir, export.BaseName(Play))
+	)
+
+	Err := export.WriteJSON(Base+".json", Play)
+	if Err == nil {
+		Err = export.WriteSentencesCSV(Base+".csv", Play)
+	}
+	if Err == nil {
+		Err = export.WritePlaysCSV(Base+"_summary.csv", []export.Play{Play})
+	}
+	if Err == nil {
+		Err = export.SaveCard(Renderer, Base+".png", DrawCard(Play))
+	}
+
+	if Err != nil {
+		Logger.Warn("Failed to export result: " + Err.Error())
+		view.exportMessage = "保存できませんでした"
+		return
+	}
+	view.exportMessage = "保存しました: " + Base
+}
+
+func (view *resultView) freeMusic() {
+	if view.music != nil {
+		view.music.Free()
+		view.music = nil
+	}
 }
 
 func (view *resultView) Draw(Renderer *sdl.Renderer) {
+	view.stopSnippetAtEnd()
+
 	Renderer.SetDrawColor(255, 243, 224, 0)
 	Renderer.Clear()
 
+	Page := pages[view.page]
+
 	Components := []component.Drawable{
 		top.SongInfo(view.result.MapInfo),
-		center.RankText(view.result.Rank),
-		center.ScoreText(view.result.Point, view.result.Accuracy, view.result.AchievementRate, view.result.Rank),
-		center.SpeedGauge(view.result.TypeSpeed),
-		bottom.KeyText(),
+		top.PlayMode(view.result.playMode()),
 	}
+	Components = append(Components, Page.components(view)...)
+	Components = append(Components,
+		bottom.HelpText(view.exportMessage),
+		bottom.PageText(view.page, len(pages), Page.title),
+	)
 
 	for _, v := range Components {
 		v(Renderer)
@@ -0,0 +1,72 @@
+package list
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/area"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/library"
+	"musicaltyper-go/game/rank"
+	"musicaltyper-go/game/view/songselect/component"
+	"strings"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	// VisibleRows is how many songs are shown at once
+	VisibleRows = 8
+
+	rowsY     = 62
+	rowHeight = 52
+)
+
+// SongList draws songs from Scroll, with personal bests by map hash. Selected song is highlighted.
+func SongList(Songs []library.Song, Bests map[string]history.Record, Scroll, Selected int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if len(Songs) == 0 {
+			helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth/2, 200), helper.Center, helper.FullFont, "曲が見つかりません", constants.TextColor.Brighter(50))
+			return
+		}
+
+		for i := 0; i < VisibleRows && Scroll+i < len(Songs); i++ {
+			var (
+				Index = Scroll + i
+				Song  = Songs[Index]
+				Y     = rowsY + i*rowHeight
+			)
+
+			if Index == Selected {
+				helper.DrawFillRect(Renderer, constants.BlueThickColor.Brighter(150), area.FromXYWH(0, Y, constants.WindowWidth, rowHeight-2))
+			}
+
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin, Y+2), helper.LeftAlign, helper.FullFont, Song.Title, constants.TextColor)
+			helper.DrawText(Renderer, pos.FromXY(constants.Margin, Y+30), helper.LeftAlign, helper.SystemFont, details(Song), constants.TextColor.Brighter(50))
+
+			if Best, Exists := Bests[Song.MapHash]; Exists {
+				Rank := rank.ForRule(Best.RuleID).FromAchievementRate(Best.AchievementRate)
+				helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, Y+2), helper.RightAlign, helper.FullFont, Rank.Text(), Rank.Color())
+				helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, Y+30), helper.RightAlign, helper.SystemFont, fmt.Sprintf("%.2f%%", Best.AchievementRate*100), constants.TextColor)
+			}
+		}
+	}
+}
+
+// details returns artist, score author, length and difficulty of the song
+func details(Song library.Song) string {
+	Texts := make([]string, 0, 5)
+	if Artist := Song.Artist(); Artist != "" {
+		Texts = append(Texts, Artist)
+	}
+	if Song.ScoreAuthor != "" {
+		Texts = append(Texts, "譜面 "+Song.ScoreAuthor)
+	}
+	Texts = append(Texts, fmt.Sprintf("%d:%02d", int(Song.Length)/60, int(Song.Length)%60))
+	Texts = append(Texts, fmt.Sprintf("%.1fkey/s", Song.KPS))
+	if Song.Difficulty != "" {
+		Texts = append(Texts, "難易度 "+Song.Difficulty)
+	}
+	return strings.Join(Texts, "  ")
+}
@@ -0,0 +1,28 @@
+package top
+
+import (
+	"fmt"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/library"
+	"musicaltyper-go/game/view/songselect/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// SearchBox draws search query being typed, sort order and count of songs found
+func SearchBox(Query string, SortKey library.SortKey, Count int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		Text := "検索: " + Query + "_"
+		if Query == "" {
+			Text = "検索: (文字を打って絞り込み)"
+		}
+		helper.DrawTextWithoutCache(Renderer, pos.FromXY(constants.Margin, 12), helper.LeftAlign, helper.FullFont, Text, constants.TextColor)
+
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, 8), helper.RightAlign, helper.SystemFont, "並び: "+SortKey.String(), constants.TextColor.Brighter(50))
+		helper.DrawText(Renderer, pos.FromXY(constants.WindowWidth-constants.Margin, 28), helper.RightAlign, helper.SystemFont, fmt.Sprintf("%d曲", Count), constants.TextColor.Brighter(50))
+
+		helper.DrawThickLine(Renderer, pos.FromXY(0, 55), pos.FromXY(constants.WindowWidth, 55), constants.TextColor.Brighter(100), 2)
+	}
+}
@@ -0,0 +1,116 @@
+package songselect
+
+import (
+	"musicaltyper-go/game/audio"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/logger"
+	"sync"
+)
+
+const (
+	// previewDelay is frames to wait for selection to settle before loading preview
+	previewDelay = 20
+	// previewStart is where preview begins, relative to length of the song
+	previewStart = 0.3
+)
+
+// previewRequest is loading of a song in background. Decoding whole song takes a while.
+type previewRequest struct {
+	mutex     sync.Mutex
+	cancelled bool
+	done      chan *audio.Player
+}
+
+// requestPreview starts loading the song, and the player is sent to done. nil is sent if failed.
+func requestPreview(SongData string) *previewRequest {
+	Request := &previewRequest{
+		done: make(chan *audio.Player, 1),
+	}
+
+	go func() {
+		Logger := logger.NewLogger("LoadPreview")
+		Player, Err := audio.Load(SongData)
+		if Err != nil {
+			Logger.Warn("Failed to load preview: " + Err.Error())
+			Request.done <- nil
+			return
+		}
+
+		Request.mutex.Lock()
+		defer Request.mutex.Unlock()
+		if Request.cancelled {
+			Player.Free()
+			return
+		}
+		Request.done <- Player
+	}()
+	return Request
+}
+
+// cancel throws away the song being loaded
+func (r *previewRequest) cancel() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cancelled = true
+	select {
+	case Player := <-r.done:
+		if Player != nil {
+			Player.Free()
+		}
+	default:
+	}
+}
+
+// updatePreview plays preview of the selected song once selection settles, and loops it
+func (v *songSelectView) updatePreview() {
+	Want := ""
+	if Song, ok := v.selectedSong(); ok {
+		Want = Song.SongData
+	}
+
+	if Want != v.previewFor {
+		v.stopPreview()
+		v.previewFor = Want
+		v.previewWait = previewDelay
+	}
+
+	if v.previewWait > 0 {
+		v.previewWait--
+		if v.previewWait == 0 && Want != "" {
+			v.previewRequest = requestPreview(Want)
+		}
+	}
+
+	if v.previewRequest != nil {
+		select {
+		case Player := <-v.previewRequest.done:
+			v.previewRequest = nil
+			if Player != nil {
+				Player.SetVolume(constants.MusicVolume)
+				Player.Seek(Player.Length() * previewStart)
+				Player.Play()
+				v.previewPlayer = Player
+			}
+		default:
+		}
+	}
+
+	if v.previewPlayer != nil && v.previewPlayer.IsFinished() {
+		v.previewPlayer.Seek(v.previewPlayer.Length() * previewStart)
+	}
+}
+
+// stopPreview stops and frees the preview, including one being loaded
+func (v *songSelectView) stopPreview() {
+	if v.previewRequest != nil {
+		v.previewRequest.cancel()
+		v.previewRequest = nil
+	}
+	if v.previewPlayer != nil {
+		v.previewPlayer.Free()
+		v.previewPlayer = nil
+	}
+	v.previewFor = ""
+	v.previewWait = 0
+}
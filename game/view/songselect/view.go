@@ -0,0 +1,251 @@
+package songselect
+
+import (
+	"musicaltyper-go/game/audio"
+	"musicaltyper-go/game/config"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/library"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/view"
+	mainview "musicaltyper-go/game/view/game"
+	"musicaltyper-go/game/view/songselect/component"
+	"musicaltyper-go/game/view/songselect/component/bottom"
+	"musicaltyper-go/game/view/songselect/component/list"
+	"musicaltyper-go/game/view/songselect/component/top"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	// watchInterval is interval to look for changes in song directories
+	watchInterval = 2 * time.Second
+)
+
+type songSelectView struct {
+	library   *library.Library
+	stopWatch func()
+	// version is version of the library which songs are made from
+	version int
+
+	options  mainview.PlayOptions
+	nextView view.View
+	// closing means the view asked to go back to the previous view
+	closing bool
+	message string
+
+	// bests are personal bests by map hash
+	bests map[string]history.Record
+
+	query    string
+	sortKey  library.SortKey
+	songs    []library.Song
+	selected int
+	scroll   int
+
+	previewFor     string
+	previewWait    int
+	previewRequest *previewRequest
+	previewPlayer  *audio.Player
+}
+
+// NewSongSelectView makes view to pick a song from the library. Picked song is played with Options.
+func NewSongSelectView(Library *library.Library, Options mainview.PlayOptions) view.View {
+	Result := songSelectView{}
+	Result.library = Library
+	Result.options = Options
+	return &Result
+}
+
+func (v *songSelectView) GetName() string {
+	return "SongSelectView"
+}
+
+// OnEnter starts watching song directories, and reloads personal bests which may have been updated by play
+func (v *songSelectView) OnEnter() {
+	v.stopWatch = v.library.Watch(watchInterval)
+	v.version = -1
+}
+
+// OnExit stops preview and watching song directories
+func (v *songSelectView) OnExit() {
+	v.stopPreview()
+	v.stopWatch()
+}
+
+// loadBests reads personal bests of every song in history
+func (v *songSelectView) loadBests() {
+	Logger := logger.NewLogger("SongSelect")
+	v.bests = map[string]history.Record{}
+
+	Store, Err := history.Open(config.Get().HistoryPath)
+	if Err != nil {
+		Logger.Warn("Failed to open history: " + Err.Error())
+		return
+	}
+	//Bests are of the rate and mods which songs will be played with.
+	Rate := v.options.Rate
+	if Rate == 0 {
+		Rate = 1
+	}
+	for _, Song := range v.library.Songs() {
+		RuleID := Song.ScoringRule
+		if RuleID == "" {
+			RuleID = config.Get().ScoringRule
+		}
+		if Best, Exists := Store.PersonalBest(Song.MapHash, RuleID, Rate, v.options.Mods); Exists {
+			v.bests[Song.MapHash] = Best
+		}
+	}
+}
+
+// updateSongs searches and sorts songs again, keeping the selected song if possible
+func (v *songSelectView) updateSongs() {
+	Previous, Selected := v.selectedSong()
+
+	Rates := map[string]float64{}
+	for Hash, Best := range v.bests {
+		Rates[Hash] = Best.AchievementRate
+	}
+	v.songs = library.Search(v.library.Songs(), v.query)
+	library.Sort(v.songs, v.sortKey, Rates)
+
+	v.selectSong(0)
+	if Selected {
+		for i, Song := range v.songs {
+			if Song.Path == Previous.Path {
+				v.selectSong(i)
+				break
+			}
+		}
+	}
+}
+
+func (v *songSelectView) selectedSong() (library.Song, bool) {
+	if v.selected >= len(v.songs) {
+		return library.Song{}, false
+	}
+	return v.songs[v.selected], true
+}
+
+// selectSong selects the song, and scrolls the list to show it
+func (v *songSelectView) selectSong(Index int) {
+	if Index >= len(v.songs) {
+		Index = len(v.songs) - 1
+	}
+	if Index < 0 {
+		Index = 0
+	}
+	v.selected = Index
+
+	if v.selected < v.scroll {
+		v.scroll = v.selected
+	}
+	if v.selected >= v.scroll+list.VisibleRows {
+		v.scroll = v.selected - list.VisibleRows + 1
+	}
+}
+
+// start plays the selected song
+func (v *songSelectView) start() {
+	Song, ok := v.selectedSong()
+	if !ok {
+		return
+	}
+
+	Map, Err := library.LoadMap(Song.Path)
+	if Err != nil {
+		Logger := logger.NewLogger("SongSelect")
+		Logger.Warn(Err.Error())
+		v.message = "譜面を読み込めませんでした: " + Song.Path
+		return
+	}
+
+	v.nextView = mainview.NewMainView(Map, v.options)
+}
+
+func (v *songSelectView) HandleSDLEvent(_ *sdl.Renderer, event sdl.Event) bool {
+	switch e := event.(type) {
+	case *sdl.TextInputEvent:
+		v.query += e.GetText()
+		v.updateSongs()
+
+	case *sdl.KeyboardEvent:
+		if e.Type != sdl.KEYDOWN {
+			return true
+		}
+		switch e.Keysym.Sym {
+		case sdl.K_ESCAPE:
+			if v.query != "" {
+				v.query = ""
+				v.updateSongs()
+				return true
+			}
+			v.closing = true
+
+		case sdl.K_UP:
+			v.selectSong(v.selected - 1)
+		case sdl.K_DOWN:
+			v.selectSong(v.selected + 1)
+		case sdl.K_PAGEUP:
+			v.selectSong(v.selected - list.VisibleRows)
+		case sdl.K_PAGEDOWN:
+			v.selectSong(v.selected + list.VisibleRows)
+
+		case sdl.K_TAB:
+			v.sortKey = v.sortKey.Next()
+			v.updateSongs()
+
+		case sdl.K_BACKSPACE:
+			if Query := []rune(v.query); len(Query) > 0 {
+				v.query = string(Query[:len(Query)-1])
+				v.updateSongs()
+			}
+
+		case sdl.K_RETURN:
+			v.start()
+		}
+
+	case *sdl.MouseWheelEvent:
+		v.selectSong(v.selected - int(e.Y))
+	}
+	return true
+}
+
+func (v *songSelectView) PollEvent() view.Event {
+	if v.closing {
+		v.closing = false
+		return &view.PopViewEvent{}
+	}
+	if v.nextView == nil {
+		return nil
+	}
+	ev := view.PushViewEvent{
+		View: v.nextView,
+	}
+	v.nextView = nil
+	return &ev
+}
+
+func (v *songSelectView) Draw(Renderer *sdl.Renderer) {
+	if Version := v.library.Version(); Version != v.version {
+		v.version = Version
+		v.loadBests()
+		v.updateSongs()
+	}
+	v.updatePreview()
+
+	Renderer.SetDrawColor(255, 243, 224, 0)
+	Renderer.Clear()
+
+	Components := []component.Drawable{
+		top.SearchBox(v.query, v.sortKey, len(v.songs)),
+		list.SongList(v.songs, v.bests, v.scroll, v.selected),
+		bottom.KeyText(v.message),
+	}
+	for _, v := range Components {
+		v(Renderer)
+	}
+
+	Renderer.Present()
+}
@@ -0,0 +1,74 @@
+package view
+
+// Stack holds views navigated into. Only the top view is shown.
+type Stack struct {
+	views []View
+}
+
+// Top returns the view shown now, or nil if empty
+func (s *Stack) Top() View {
+	if len(s.views) == 0 {
+		return nil
+	}
+	return s.views[len(s.views)-1]
+}
+
+// IsEmpty returns whether no views remain
+func (s *Stack) IsEmpty() bool {
+	return len(s.views) == 0
+}
+
+// Push shows the view over current one
+func (s *Stack) Push(v View) {
+	if Top := s.Top(); Top != nil {
+		Top.OnExit()
+	}
+	s.views = append(s.views, v)
+	v.OnEnter()
+}
+
+// Pop closes current view, and shows the previous one again
+func (s *Stack) Pop() {
+	Top := s.Top()
+	if Top == nil {
+		return
+	}
+	Top.OnExit()
+	s.views[len(s.views)-1] = nil
+	s.views = s.views[:len(s.views)-1]
+
+	if Top := s.Top(); Top != nil {
+		Top.OnEnter()
+	}
+}
+
+// Replace closes current view and shows the view instead
+func (s *Stack) Replace(v View) {
+	if s.IsEmpty() {
+		s.Push(v)
+		return
+	}
+	s.Top().OnExit()
+	s.views[len(s.views)-1] = v
+	v.OnEnter()
+}
+
+// Clear closes all views without showing the ones below
+func (s *Stack) Clear() {
+	if Top := s.Top(); Top != nil {
+		Top.OnExit()
+	}
+	s.views = nil
+}
+
+// Apply changes views as the event requests
+func (s *Stack) Apply(ev Event) {
+	switch e := ev.(type) {
+	case *ChangeViewEvent:
+		s.Replace(e.ToChangeView)
+	case *PushViewEvent:
+		s.Push(e.View)
+	case *PopViewEvent:
+		s.Pop()
+	}
+}
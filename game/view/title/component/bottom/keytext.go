@@ -0,0 +1,20 @@
+package bottom
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/view/title/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// KeyText draws message of the last operation, or available keys if Message is empty
+func KeyText(Message string) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		if Message == "" {
+			Message = "[↑↓]/選択 [Enter]/決定 [Esc]/終了"
+		}
+		helper.DrawText(Renderer, pos.FromXY(constants.Margin, constants.WindowHeight-35), helper.LeftAlign, helper.SystemFont, Message, constants.TextColor)
+	}
+}
@@ -0,0 +1,38 @@
+package center
+
+import (
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/draw/helper"
+	"musicaltyper-go/game/draw/pos"
+	"musicaltyper-go/game/view/title/component"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Logo draws title of the game
+func Logo() component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		helper.DrawText(Renderer,
+			pos.FromXY(constants.WindowWidth/2, 110),
+			helper.Center, helper.BigFont,
+			constants.WindowTitle, constants.TextColor)
+	}
+}
+
+// Menu draws menu items, marking the selected one
+func Menu(Items []string, Selected int) component.Drawable {
+	return func(Renderer *sdl.Renderer) {
+		for i, v := range Items {
+			Color := constants.TextColor
+			Text := v
+			if i == Selected {
+				Color = constants.BlueThickColor
+				Text = "> " + v + " <"
+			}
+			helper.DrawText(Renderer,
+				pos.FromXY(constants.WindowWidth/2, 270+50*i),
+				helper.Center, helper.FullFont,
+				Text, Color)
+		}
+	}
+}
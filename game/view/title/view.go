@@ -0,0 +1,173 @@
+package title
+
+import (
+	"math/rand"
+	"musicaltyper-go/game/autoplay"
+	"musicaltyper-go/game/constants"
+	"musicaltyper-go/game/library"
+	"musicaltyper-go/game/logger"
+	"musicaltyper-go/game/view"
+	mainview "musicaltyper-go/game/view/game"
+	"musicaltyper-go/game/view/songselect"
+	"musicaltyper-go/game/view/title/component"
+	"musicaltyper-go/game/view/title/component/bottom"
+	"musicaltyper-go/game/view/title/component/center"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const (
+	// demoIdleSeconds is how long the title waits without input before starting demo
+	demoIdleSeconds = 20
+)
+
+type menuItem uint8
+
+const (
+	songSelectItem menuItem = iota
+	demoItem
+	quitItem
+)
+
+var (
+	menuTexts = []string{"曲を選ぶ", "デモを見る", "終了"}
+)
+
+type titleView struct {
+	library *library.Library
+	options mainview.PlayOptions
+	random  *rand.Rand
+
+	nextView view.View
+	// closing means the view asked to go back to the previous view
+	closing bool
+	message string
+
+	menuIndex int
+	// idleFrames is frames since the last input
+	idleFrames int
+}
+
+// NewTitleView makes title menu. Songs in the library are played with Options, and also played by autoplay as demo.
+func NewTitleView(Library *library.Library, Options mainview.PlayOptions) view.View {
+	Result := titleView{}
+	Result.library = Library
+	Result.options = Options
+	Result.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &Result
+}
+
+func (v *titleView) GetName() string {
+	return "TitleView"
+}
+
+// OnEnter waits for input again, so demo doesn't begin right after going back to the title
+func (v *titleView) OnEnter() {
+	v.idleFrames = 0
+}
+
+func (v *titleView) OnExit() {}
+
+// startDemo plays a song picked at random by autoplay until any key is pressed
+func (v *titleView) startDemo() {
+	Songs := v.library.Songs()
+	if len(Songs) == 0 {
+		v.message = "曲が見つかりませんでした"
+		return
+	}
+
+	Song := Songs[v.random.Intn(len(Songs))]
+	Map, Err := library.LoadMap(Song.Path)
+	if Err != nil {
+		Logger := logger.NewLogger("Title")
+		Logger.Warn(Err.Error())
+		v.message = "譜面を読み込めませんでした: " + Song.Path
+		return
+	}
+
+	v.nextView = mainview.NewMainView(Map, mainview.PlayOptions{
+		Autoplay: &mainview.Autoplay{
+			KPS:    autoplay.DefaultKPS,
+			Jitter: autoplay.DefaultJitter,
+			Demo:   true,
+		},
+	})
+}
+
+// choose does what the selected menu item means
+func (v *titleView) choose() {
+	switch menuItem(v.menuIndex) {
+	case songSelectItem:
+		v.nextView = songselect.NewSongSelectView(v.library, v.options)
+
+	case demoItem:
+		v.startDemo()
+
+	case quitItem:
+		v.closing = true
+	}
+}
+
+func (v *titleView) HandleSDLEvent(_ *sdl.Renderer, event sdl.Event) bool {
+	switch e := event.(type) {
+	case *sdl.KeyboardEvent:
+		if e.Type != sdl.KEYDOWN {
+			return true
+		}
+		v.idleFrames = 0
+
+		switch e.Keysym.Sym {
+		case sdl.K_ESCAPE:
+			v.closing = true
+
+		case sdl.K_UP:
+			v.menuIndex = (v.menuIndex + len(menuTexts) - 1) % len(menuTexts)
+
+		case sdl.K_DOWN:
+			v.menuIndex = (v.menuIndex + 1) % len(menuTexts)
+
+		case sdl.K_RETURN:
+			v.message = ""
+			v.choose()
+		}
+	}
+	return true
+}
+
+func (v *titleView) PollEvent() view.Event {
+	if v.closing {
+		v.closing = false
+		return &view.PopViewEvent{}
+	}
+	if v.nextView == nil {
+		return nil
+	}
+	ev := view.PushViewEvent{
+		View: v.nextView,
+	}
+	v.nextView = nil
+	return &ev
+}
+
+func (v *titleView) Draw(Renderer *sdl.Renderer) {
+	v.idleFrames++
+	if v.idleFrames >= demoIdleSeconds*constants.FrameRate {
+		v.idleFrames = 0
+		v.startDemo()
+	}
+
+	Renderer.SetDrawColor(255, 243, 224, 0)
+	Renderer.Clear()
+
+	Components := []component.Drawable{
+		center.Logo(),
+		center.Menu(menuTexts, v.menuIndex),
+		bottom.KeyText(v.message),
+	}
+	for _, v := range Components {
+		v(Renderer)
+	}
+
+	Renderer.Present()
+}
@@ -4,9 +4,16 @@ import (
 	"github.com/veandco/go-sdl2/sdl"
 )
 
+// View is a screen of the game. Views are stacked, and only the top one is shown.
 type View interface {
 	GetName() string
 
+	// OnEnter is called when the view comes to the top, also when the view above it has been popped
+	OnEnter()
+	// OnExit is called when the view leaves the top by being popped, replaced or covered. Free resources here.
+	OnExit()
+
+	// HandleSDLEvent handles input. Returns false to quit the game.
 	HandleSDLEvent(*sdl.Renderer, sdl.Event) bool
 
 	PollEvent() Event
@@ -22,8 +29,11 @@ type EventType uint8
 
 const (
 	CHANGE_VIEW EventType = iota
+	PUSH_VIEW
+	POP_VIEW
 )
 
+// ChangeViewEvent replaces current view
 type ChangeViewEvent struct {
 	ToChangeView View //fixme: 変数名何とかしろ
 }
@@ -31,3 +41,19 @@ type ChangeViewEvent struct {
 func (ev *ChangeViewEvent) GetType() EventType {
 	return CHANGE_VIEW
 }
+
+// PushViewEvent shows the view over current view. Popping it goes back to current view.
+type PushViewEvent struct {
+	View View
+}
+
+func (ev *PushViewEvent) GetType() EventType {
+	return PUSH_VIEW
+}
+
+// PopViewEvent closes current view and goes back to the previous one. Popping the last view quits the game.
+type PopViewEvent struct{}
+
+func (ev *PopViewEvent) GetType() EventType {
+	return POP_VIEW
+}
@@ -1,21 +1,65 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	Game "musicaltyper-go/game"
+	"musicaltyper-go/game/autoplay"
 	Beatmap "musicaltyper-go/game/beatmap"
+	"musicaltyper-go/game/config"
+	"musicaltyper-go/game/export"
+	"musicaltyper-go/game/history"
+	"musicaltyper-go/game/library"
 	Logger "musicaltyper-go/game/logger"
+	"musicaltyper-go/game/mods"
+	"musicaltyper-go/game/replay"
+	"musicaltyper-go/game/scoring"
+	"musicaltyper-go/game/view"
+	MainView "musicaltyper-go/game/view/game"
+	"musicaltyper-go/game/view/result"
+	"musicaltyper-go/game/view/title"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
+)
+
+const (
+	// bestGhost is -ghost value to race against replay of personal best
+	bestGhost = "best"
+)
+
+var (
+	replayPath = flag.String("replay", "", "play back the replay file instead of playing. The song file can be omitted if it is in song directories.")
+	ghostPath  = flag.String("ghost", "", "race against the replay file while playing, or \""+bestGhost+"\" for replay of your personal best")
+
+	practiceSection = flag.String("practice", "", "practice by looping the section, e.g. Intro-A")
+	practiceNotes   = flag.String("practice-notes", "", "practice by looping the range of note indices, e.g. 10-24")
+	practiceSpeed   = flag.Float64("practice-speed", 1.0, "playback rate of the song while practicing, from 0.5 to 1.0")
+
+	rate    = flag.Float64("rate", 1.0, "playback rate of whole song: 0.75, 1, 1.25 or 1.5")
+	modList = flag.String("mods", "", "gameplay modifiers separated by comma: "+strings.Join(mods.Names(), ", "))
+
+	autoplayEnabled = flag.Bool("autoplay", false, "let autoplay type instead of you")
+	autoplayDemo    = flag.Bool("demo", false, "loop autoplay as demo screen until any key is pressed")
+	autoplayKPS     = flag.Float64("autoplay-kps", autoplay.DefaultKPS, "typing speed of autoplay in keys per second")
+	autoplayJitter  = flag.Float64("autoplay-jitter", autoplay.DefaultJitter, "variation of autoplay key intervals relative to average")
+	checkKPS        = flag.Float64("check-kps", 0, "check whether autoplay clears the chart at the typing speed without window, and exit")
+
+	exportCount = flag.Int("export-history", 0, "export the latest plays in history as JSON, CSV and image cards, and exit")
 )
 
 // InitMap makes Beatmap from commandline arguments
 func InitMap() *Beatmap.Beatmap {
 	logger := Logger.NewLogger("Main")
 
-	if len(os.Args) < 2 {
+	if flag.NArg() < 1 {
+		if *replayPath != "" {
+			return InitReplayMap()
+		}
 		logger.FatalError("Song file is not specified.")
 	}
-	BeatMapPath := os.Args[1]
+	BeatMapPath := flag.Arg(0)
 	Stat, Err := os.Stat(BeatMapPath)
 	logger.CheckError(Err)
 
@@ -26,10 +70,239 @@ func InitMap() *Beatmap.Beatmap {
 	return Beatmap.LoadMap(BeatMapPath)
 }
 
+// InitReplayMap finds the beatmap which the replay was recorded on in song directories
+func InitReplayMap() *Beatmap.Beatmap {
+	logger := Logger.NewLogger("Main")
+
+	Replay, Err := replay.Load(*replayPath)
+	logger.CheckError(Err)
+	Library, Err := library.Open(config.Get().SongDirs, config.Get().LibraryCachePath)
+	logger.CheckError(Err)
+
+	for _, Song := range Library.Songs() {
+		if Song.MapHash == Replay.MapHash {
+			return Beatmap.LoadMap(Song.Path)
+		}
+	}
+	logger.FatalError("Beatmap of the replay isn't found in song directories. Specify the song file after -replay, e.g. -replay play.mtr song.tsc")
+	return nil //won't reach here.
+}
+
+// InitView decides first view from commandline arguments
+func InitView(Map *Beatmap.Beatmap) func() view.View {
+	logger := Logger.NewLogger("Main")
+
+	if *replayPath != "" {
+		Replay, Err := replay.Load(*replayPath)
+		logger.CheckError(Err)
+		return func() view.View {
+			return MainView.NewReplayView(Map, Replay)
+		}
+	}
+
+	Options := InitOptions()
+	if *ghostPath != "" {
+		Options.Ghost = InitGhost(Map, Options.Rate, Options.Mods)
+	}
+	if *practiceSection != "" || *practiceNotes != "" {
+		Options.Practice = InitPractice(Map)
+	}
+
+	return func() view.View {
+		return MainView.NewMainView(Map, Options)
+	}
+}
+
+// InitGhost loads replay to race against from commandline arguments. Personal best is looked up at the playback rate and mods.
+func InitGhost(Map *Beatmap.Beatmap, Rate float64, Mods mods.Mod) *replay.Replay {
+	logger := Logger.NewLogger("Main")
+
+	Path := *ghostPath
+	if Path == bestGhost {
+		Store, Err := history.Open(config.Get().HistoryPath)
+		logger.CheckError(Err)
+
+		Best, Found := Store.BestReplay(Map.Fingerprint, scoring.ForBeatmap(Map).ID(), Rate, Mods)
+		if !Found {
+			logger.FatalError("No replay of personal best is saved for the beatmap.")
+		}
+		Path = Best.Replay
+	}
+
+	Ghost, Err := replay.Load(Path)
+	logger.CheckError(Err)
+	return Ghost
+}
+
+// InitOptions makes options of playing any song from commandline arguments
+func InitOptions() MainView.PlayOptions {
+	logger := Logger.NewLogger("Main")
+
+	Options := MainView.PlayOptions{}
+	Options.Rate = InitRate()
+	Mods, Err := mods.Parse(*modList)
+	logger.CheckError(Err)
+	Options.Mods = Mods
+
+	if *autoplayEnabled || *autoplayDemo {
+		Options.Autoplay = &MainView.Autoplay{
+			KPS:    InitKPS("Autoplay speed", *autoplayKPS),
+			Jitter: *autoplayJitter,
+			Demo:   *autoplayDemo,
+		}
+	}
+	return Options
+}
+
+// InitTitle makes title view, which plays songs in configured song directories
+func InitTitle() func() view.View {
+	logger := Logger.NewLogger("Main")
+
+	Library, Err := library.Open(config.Get().SongDirs, config.Get().LibraryCachePath)
+	logger.CheckError(Err)
+	Options := InitOptions()
+
+	return func() view.View {
+		return title.NewTitleView(Library, Options)
+	}
+}
+
+// InitRate checks playback rate from commandline arguments
+func InitRate() float64 {
+	for _, v := range MainView.PlayRates {
+		if *rate == v {
+			return v
+		}
+	}
+
+	logger := Logger.NewLogger("Main")
+	logger.FatalError(fmt.Sprint("Rate must be one of ", MainView.PlayRates, "."))
+	return 0 //won't reach here.
+}
+
+// InitKPS checks typing speed of autoplay from commandline arguments
+func InitKPS(Name string, KPS float64) float64 {
+	if KPS > 0 {
+		return KPS
+	}
+
+	logger := Logger.NewLogger("Main")
+	logger.FatalError(Name + " must be more than 0 keys per second.")
+	return 0 //won't reach here.
+}
+
+// isFlagSet returns whether the flag is given in commandline arguments
+func isFlagSet(Name string) bool {
+	Result := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == Name {
+			Result = true
+		}
+	})
+	return Result
+}
+
+// InitPractice makes settings of practice mode from commandline arguments
+func InitPractice(Map *Beatmap.Beatmap) *MainView.Practice {
+	logger := Logger.NewLogger("Main")
+
+	Practice := &MainView.Practice{
+		Rate:     *practiceSpeed,
+		LastNote: -1,
+	}
+	if *practiceSpeed < MainView.PracticeMinRate || *practiceSpeed > MainView.PracticeMaxRate {
+		logger.FatalError(fmt.Sprintf("Practice speed must be from %.1f to %.1f.", MainView.PracticeMinRate, MainView.PracticeMaxRate))
+	}
+
+	if *practiceNotes != "" {
+		_, Err := fmt.Sscanf(*practiceNotes, "%d-%d", &Practice.FirstNote, &Practice.LastNote)
+		if Err != nil || Practice.FirstNote < 0 || Practice.LastNote < Practice.FirstNote {
+			logger.FatalError("Practice note range must be like 10-24.")
+		}
+		return Practice
+	}
+
+	ID := strings.TrimPrefix(*practiceSection, "@")
+	for i, Section := range Map.Sections {
+		if Section.ID == ID {
+			Practice.Section = i
+			return Practice
+		}
+	}
+	logger.FatalError("Section " + ID + " doesn't exist in the beatmap.")
+	return nil //won't reach here.
+}
+
+// CheckChart prints whether autoplay clears the chart at the typing speed
+func CheckChart(Map *Beatmap.Beatmap) {
+	Check := MainView.CheckChart(Map, InitKPS("Checking speed", *checkKPS), *autoplayJitter)
+
+	fmt.Printf("%.1f keys/sec: achievement %.2f%%, rank %s\n", Check.KPS, Check.AchievementRate*100, Check.Rank.Text())
+	for _, v := range Check.TLENotes {
+		Note := Map.Notes[v]
+		fmt.Printf("  TLE at %.2fs (note %d): %s\n", Note.Time, v, Note.Sentence.OriginalSentence)
+	}
+
+	switch {
+	case Check.Failed:
+		fmt.Println("Failed: life gauge has run out.")
+		os.Exit(1)
+	case !Check.Cleared():
+		fmt.Println("Not cleared.")
+		os.Exit(1)
+	default:
+		fmt.Println("Cleared.")
+	}
+}
+
+// ExportHistory exports the latest plays in history into export directory
+func ExportHistory() {
+	logger := Logger.NewLogger("Main")
+
+	Store, Err := history.Open(config.Get().HistoryPath)
+	logger.CheckError(Err)
+
+	Records := Store.Recent(*exportCount)
+	Plays := make([]export.Play, 0, len(Records))
+	for _, v := range Records {
+		Plays = append(Plays, export.FromRecord(v))
+	}
+
+	Dir := config.Get().ExportDir
+	logger.CheckError(export.WriteJSON(filepath.Join(Dir, "history.json"), Plays))
+	logger.CheckError(export.WritePlaysCSV(filepath.Join(Dir, "history.csv"), Plays))
+
+	Renderer, Close, Err := export.NewHeadlessRenderer()
+	logger.CheckError(Err)
+	defer Close()
+
+	for _, v := range Plays {
+		logger.CheckError(export.SaveCard(Renderer, filepath.Join(Dir, export.BaseName(v)+".png"), result.DrawCard(v)))
+	}
+	fmt.Printf("Exported %d plays to %s\n", len(Plays), Dir)
+}
+
 func main() {
 	//Be sure this goroutine to run on main thread.
 	runtime.LockOSThread()
+	flag.Parse()
+	config.Load(config.DefaultPath)
+
+	if *exportCount > 0 {
+		ExportHistory()
+		return
+	}
+
+	Checking := isFlagSet("check-kps")
+	if flag.NArg() < 1 && !Checking && *replayPath == "" {
+		Game.Run(InitTitle())
+		return
+	}
 
 	Map := InitMap()
-	Game.Run(Map)
+	if Checking {
+		CheckChart(Map)
+		return
+	}
+	Game.Run(InitView(Map))
 }